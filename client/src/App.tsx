@@ -8,12 +8,13 @@ import Game from "./components/Game";
 const App = () => {
   const [clientId, setClientId] = useState<string | null>(null);
   const [host, setHost] = useState<string | null>(null);
+  const [isSpectator, setIsSpectator] = useState<boolean>(false);
 
   if (!clientId || !host) {
-    return <Form setClientId={setClientId} setHost={setHost} />;
+    return <Form setClientId={setClientId} setHost={setHost} setIsSpectator={setIsSpectator} />;
   }
 
-  return <Game clientId={clientId} host={host} />;
+  return <Game clientId={clientId} host={host} isSpectator={isSpectator} />;
 };
 
 export default App;
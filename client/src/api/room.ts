@@ -1,11 +1,25 @@
-import { JoinRequest, JoinResponse } from "../pb/join";
+import { GameMode } from "../pb/event";
+import {
+  CreateRoomRequest,
+  CreateRoomResponse,
+  JoinRequest,
+  JoinResponse,
+  ResumeRequest,
+} from "../pb/join";
 
 const ROOT_HOST = import.meta.env.VITE_ROOT_HOST;
 
-export async function joinRoom(username: string, roomId: string): Promise<JoinResponse> {
+export async function joinRoom(
+  username: string,
+  roomId: string,
+  spectate: boolean,
+  password: string,
+): Promise<JoinResponse> {
   const body: JoinRequest = {
     username,
     ...(roomId === "" ? {} : { roomId: roomId }),
+    spectate,
+    ...(password === "" ? {} : { password: password }),
   };
   const payload = {
     method: "POST",
@@ -27,3 +41,62 @@ export async function joinRoom(username: string, roomId: string): Promise<JoinRe
       return joinResponse;
     });
 }
+
+export async function resumeRoom(): Promise<JoinResponse> {
+  const token = localStorage.getItem("jwt");
+  if (!token) {
+    return Promise.reject(new Error("missing token"));
+  }
+
+  const body: ResumeRequest = { token };
+  const payload = {
+    method: "POST",
+    body: ResumeRequest.encode(body).finish(),
+  };
+
+  return await fetch(`http://${ROOT_HOST}/api/resume`, payload)
+    .then(async response => {
+      if (!response.ok) {
+        const message = await response.text();
+        throw new Error(message);
+      }
+      return response.arrayBuffer();
+    })
+    .then(buffer => {
+      const message = new Uint8Array(buffer);
+      const joinResponse = JoinResponse.decode(message);
+      localStorage.setItem("jwt", joinResponse.token);
+      return joinResponse;
+    });
+}
+
+export async function createRoom(
+  password: string,
+  mode: GameMode,
+): Promise<CreateRoomResponse> {
+  const body: CreateRoomRequest = {
+    ...(password === "" ? {} : { password: password }),
+    maxPlayers: 0,
+    mode,
+    scoreLimit: 0,
+    roundDuration: 0,
+    worldSize: 0,
+  };
+  const payload = {
+    method: "POST",
+    body: CreateRoomRequest.encode(body).finish(),
+  };
+
+  return await fetch(`http://${ROOT_HOST}/api/room`, payload)
+    .then(async response => {
+      if (!response.ok) {
+        const message = await response.text();
+        throw new Error(message);
+      }
+      return response.arrayBuffer();
+    })
+    .then(buffer => {
+      const message = new Uint8Array(buffer);
+      return CreateRoomResponse.decode(message);
+    });
+}
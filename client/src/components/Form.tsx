@@ -5,17 +5,21 @@ import { GoAlert } from "react-icons/go";
 import { TiArrowShuffle } from "react-icons/ti";
 import { generateUsername } from "unique-username-generator";
 
-import { joinRoom } from "../api/room";
+import { createRoom, joinRoom } from "../api/room";
 import Spritesheet from "../game/graphics/sprites";
+import { GameMode } from "../pb/event";
 
 type Props = {
   setClientId: (clientId: string) => void;
   setHost: (host: string) => void;
+  setIsSpectator: (isSpectator: boolean) => void;
 };
 
-const Form: React.FC<Props> = ({ setClientId, setHost }) => {
+const Form: React.FC<Props> = ({ setClientId, setHost, setIsSpectator }) => {
   const [username, setUsername] = useState<string>(generateUsername("-"));
   const [roomId, setRoomId] = useState<string>("");
+  const [password, setPassword] = useState<string>("");
+  const [mode, setMode] = useState<GameMode>(GameMode.GAME_MODE_FREE_FOR_ALL);
 
   const [shouldShake, setShouldShake] = useState<boolean>(false);
   const [errorMessage, setErrorMessage] = useState<string | null>(null);
@@ -26,21 +30,41 @@ const Form: React.FC<Props> = ({ setClientId, setHost }) => {
 
   const onSubmit = async (event: React.FormEvent<HTMLFormElement>) => {
     event.preventDefault();
-    await joinRoom(username, roomId)
+    await join(false);
+  };
+
+  const onSpectate = async () => {
+    await join(true);
+  };
+
+  const onCreate = async () => {
+    await createRoom(password, mode)
       .then(response => {
+        setRoomId(response.roomId);
+        return join(false, response.roomId);
+      })
+      .catch(showError);
+  };
+
+  const join = async (spectate: boolean, id: string = roomId) => {
+    await joinRoom(username, id, spectate, password)
+      .then(response => {
+        setIsSpectator(response.spectator);
         setClientId(response.clientId);
         setHost(response.host);
       })
-      .catch((error: Error) => {
-        // TODO: feels a bit hacky
-        const errorMessage = error.name === "TypeError" ? "network error" : error.message;
-        setErrorMessage(errorMessage);
-
-        setShouldShake(true);
-        setTimeout(() => {
-          setShouldShake(false);
-        }, 500);
-      });
+      .catch(showError);
+  };
+
+  const showError = (error: Error) => {
+    // TODO: feels a bit hacky
+    const errorMessage = error.name === "TypeError" ? "network error" : error.message;
+    setErrorMessage(errorMessage);
+
+    setShouldShake(true);
+    setTimeout(() => {
+      setShouldShake(false);
+    }, 500);
   };
 
   const spriteSrc = useMemo(() => {
@@ -84,8 +108,37 @@ const Form: React.FC<Props> = ({ setClientId, setHost }) => {
           />
         </div>
       </div>
+      <div className="form__field">
+        <label htmlFor="password">password:</label><br />
+        <div className="form__field-wrapper">
+          <input
+            type="password"
+            id="password"
+            name="password"
+            value={password}
+            onChange={(e) => setPassword(e.target.value)}
+          />
+        </div>
+      </div>
+      <div className="form__field">
+        <label htmlFor="mode">mode:</label><br />
+        <div className="form__field-wrapper">
+          <select
+            id="mode"
+            name="mode"
+            value={mode}
+            onChange={(e) => setMode(Number(e.target.value) as GameMode)}
+          >
+            <option value={GameMode.GAME_MODE_FREE_FOR_ALL}>free for all</option>
+            <option value={GameMode.GAME_MODE_TEAM_DEATHMATCH}>team deathmatch</option>
+            <option value={GameMode.GAME_MODE_FIRST_TO_N}>first to n</option>
+          </select>
+        </div>
+      </div>
 
       <button className="form__submit" type="submit">Join</button>
+      <button className="form__submit" type="button" onClick={onSpectate}>Spectate</button>
+      <button className="form__submit" type="button" onClick={onCreate}>Create room</button>
 
       {
         errorMessage && <div className="form__error-message" role="alert">
@@ -3,15 +3,21 @@ import "./Game.css";
 import p5 from "p5";
 import { useEffect, useLayoutEffect, useRef, useState } from "react";
 
+import { resumeRoom } from "../api/room";
 import Engine from "../game/Engine";
 import { Event } from "../pb/event";
 
+const RECONNECT_ATTEMPTS = 5;
+const RECONNECT_DELAY = 1000;
+const TOKEN_REFRESH_INTERVAL = 30000; // half of the server's token TTL
+
 type Props = {
   clientId: string,
   host: string,
+  isSpectator: boolean,
 }
 
-const Game: React.FC<Props> = ({ clientId, host }) => {
+const Game: React.FC<Props> = ({ clientId, host, isSpectator }) => {
   const gameEngineRef = useRef<Engine | null>(null);
   const containerRef = useRef<HTMLDivElement>(null);
   const [socket, setSocket] = useState<WebSocket | null>(null);
@@ -35,21 +41,47 @@ const Game: React.FC<Props> = ({ clientId, host }) => {
       const message = new Uint8Array(event.data);
       gameEngineRef.current?.receive(Event.decode(message));
     };
+    ws.onclose = async () => {
+      // Resume the session with a new token, and let the effect reconnect
+      for (let attempt = 0; attempt < RECONNECT_ATTEMPTS; attempt++) {
+        await new Promise(resolve => setTimeout(resolve, RECONNECT_DELAY));
+        const resumed = await resumeRoom()
+          .then(() => true)
+          .catch(() => false);
+        if (resumed) {
+          setSocket(null);
+          return;
+        }
+      }
+    };
     setSocket(ws);
   }, [host, socket]);
 
+  // Keep a fresh token while connected, since only recently issued tokens can
+  // resume the session after the connection drops
+  useEffect(() => {
+    if (!socket) {
+      return;
+    }
+
+    const interval = setInterval(() => {
+      resumeRoom().catch(() => {});
+    }, TOKEN_REFRESH_INTERVAL);
+    return () => clearInterval(interval);
+  }, [socket]);
+
   useLayoutEffect(() => {
     if (!socket) {
       return;
     }
 
     const sketch = (instance: p5) => {
-      gameEngineRef.current = new Engine(instance, clientId, host, socket);
+      gameEngineRef.current = new Engine(instance, clientId, host, socket, isSpectator);
     };
 
     const instance = new p5(sketch, containerRef.current!);
     return () => instance.remove();
-  }, [clientId, host, socket]);
+  }, [clientId, host, socket, isSpectator]);
 
   return <div className="game__container" ref={containerRef} />;
 };
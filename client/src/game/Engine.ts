@@ -3,30 +3,50 @@ import p5 from "p5";
 import { fetchSnapshot as fetchGameSnapshotData, sendEvent } from "../api/game";
 import {
   type Event,
+  type Event_DeathEventData,
   type Event_DeltaEventData,
   Event_JoinEventData,
   Event_QuitEventData,
+  type Event_RoundEventData,
   EventType,
 } from "../pb/event";
 import Audiosheet from "./audio/audio";
 import type { EntityMap } from "./entities/Entity";
 import Player from "./entities/Player";
 import { type AnimationStep } from "./graphics/animation";
-import { type CanvasConfig, type GraphicsGameContext, type GraphicsGUIContext, initCanvasConfig } from "./graphics/context";
+import {
+  type CanvasConfig,
+  type GraphicsGameContext,
+  type GraphicsGUIContext,
+  initCanvasConfig,
+  initWorldConfig,
+  type KillFeedEntry,
+  type WorldConfig,
+} from "./graphics/context";
 import {
   centerCanvas,
   drawAnimations,
   drawBackground,
   drawEntities,
+  drawWorldEdge,
   updateCanvasConfig,
 } from "./graphics/game";
 import {
   drawHUD,
+  drawKillFeed,
   drawMinimap,
   drawRespawnPrompt,
+  drawRound,
 } from "./graphics/gui";
 import Spritesheet from "./graphics/sprites";
-import { convertInputToEvent, handleMouseMove, handleMousePress, initInput, type Input } from "./logic/input";
+import {
+  convertInputToEvent,
+  convertInputToSpectateEvent,
+  handleMouseMove,
+  handleMousePress,
+  initInput,
+  type Input,
+} from "./logic/input";
 import {
   initDelta,
   mergeDeltas,
@@ -37,6 +57,7 @@ import {
 } from "./logic/update";
 
 const FPS = 60;
+const KILL_FEED_SIZE = 5;
 
 /**
  * Represents the game.
@@ -49,12 +70,20 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
   clientId: string;
   host: string;
   socket: WebSocket;
+  isSpectator: boolean;
+  followedId: string | null;
 
   entities: EntityMap;
   delta: Event_DeltaEventData;
   input: Input;
+  inputSequence: number;
+  lastProcessedInput: number;
+  tick: number;
+  round: Event_RoundEventData | null;
+  killFeed: KillFeedEntry[];
 
   canvasConfig: CanvasConfig;
+  worldConfig: WorldConfig;
   foregroundAnimations: AnimationStep[];
   backgroundAnimations: AnimationStep[];
 
@@ -63,6 +92,7 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
     clientId: string,
     host: string,
     socket: WebSocket,
+    isSpectator: boolean,
   ) {
     this.instance = instance;
     this.instance.setup = this.setup;
@@ -72,12 +102,20 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
     this.clientId = clientId;
     this.host = host;
     this.socket = socket;
+    this.isSpectator = isSpectator;
+    this.followedId = null;
 
     this.entities = {};
     this.delta = initDelta();
     this.input = initInput();
+    this.inputSequence = 0;
+    this.lastProcessedInput = 0;
+    this.tick = 0;
+    this.round = null;
+    this.killFeed = [];
 
     this.canvasConfig = initCanvasConfig();
+    this.worldConfig = initWorldConfig();
     this.foregroundAnimations = [];
     this.backgroundAnimations = [];
   }
@@ -106,6 +144,7 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
     this.instance.push();
     centerCanvas(this);
     drawBackground(this);
+    drawWorldEdge(this);
     drawAnimations(this, this.backgroundAnimations);
     drawEntities(this);
     drawAnimations(this, this.foregroundAnimations);
@@ -113,7 +152,11 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
 
     drawMinimap(this);
     drawHUD(this);
-    drawRespawnPrompt(this);
+    drawRound(this);
+    drawKillFeed(this);
+    if (!this.isSpectator) {
+      drawRespawnPrompt(this);
+    }
   };
 
   /**
@@ -122,7 +165,8 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
   mousePressed = () => {
     this.input = handleMousePress(this.input);
 
-    if (this.getClientPlayer()) {
+    const clientPlayer = this.getClientPlayer();
+    if (!this.isSpectator && clientPlayer && !clientPlayer.isOverheated) {
       Audiosheet.get("shoot")?.play();
     }
   };
@@ -157,6 +201,14 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
       this.handleDelta(event.deltaEventData!);
       break;
 
+    case EventType.EVENT_TYPE_ROUND:
+      this.handleRound(event.roundEventData!);
+      break;
+
+    case EventType.EVENT_TYPE_DEATH:
+      this.handleDeath(event.deathEventData!);
+      break;
+
     default:
       return;
     }
@@ -166,14 +218,26 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
   //  Getters (provides context to other modules)
   // ==========================================================================
 
+  /**
+   * Returns the client's player, or the followed player for spectators.
+   */
   getClientPlayer = () => {
-    return this.entities[this.clientId] as Player;
+    const id = this.isSpectator ? this.followedId : this.clientId;
+    return (id ? this.entities[id] : undefined) as Player;
   };
 
   getInput = () => {
     return this.input;
   };
 
+  getRound = () => {
+    return this.round;
+  };
+
+  getKillFeed = () => {
+    return this.killFeed;
+  };
+
   addAnimation = (animation: AnimationStep, isForeground: boolean) => {
     const target = isForeground ? this.foregroundAnimations : this.backgroundAnimations;
     target.push(animation);
@@ -200,6 +264,33 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
    */
   private handleDelta = (data: Event_DeltaEventData) => {
     this.delta = mergeDeltas(this.delta, data);
+    this.tick = Math.max(this.tick, data.tick);
+    this.lastProcessedInput = Math.max(this.lastProcessedInput, data.inputSequence);
+    this.worldConfig.zoneSize = data.zoneSize;
+  };
+
+  /**
+   * Stores the state of the current round.
+   * @param data incoming data
+   */
+  private handleRound = (data: Event_RoundEventData) => {
+    this.round = data;
+  };
+
+  /**
+   * Adds a death to the kill feed. Usernames are looked up now, since the
+   * victim is removed by the next delta.
+   * @param data incoming data
+   */
+  private handleDeath = (data: Event_DeathEventData) => {
+    const getUsername = (id: string) => (this.entities[id] as Player | undefined)?.username ?? id;
+    this.killFeed.push({
+      killer: data.killerId ? getUsername(data.killerId) : null,
+      victim: getUsername(data.victimId),
+      cause: data.cause,
+      receivedAt: Date.now(),
+    });
+    this.killFeed = this.killFeed.slice(-KILL_FEED_SIZE);
   };
 
   /**
@@ -207,11 +298,46 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
    * @param data incoming data
    */
   private handleInput = () => {
+    if (this.isSpectator) {
+      this.handleSpectate();
+      return;
+    }
+
     this.input = handleMouseMove(this.input, this.instance);
 
     const clientPlayer = this.getClientPlayer();
-    const [input, event] = convertInputToEvent(this.input, this.clientId, !clientPlayer);
+    const [input, event] = convertInputToEvent(
+      this.input,
+      this.clientId,
+      !clientPlayer,
+      this.inputSequence + 1,
+      this.tick,
+    );
+    if (event?.inputEventData) {
+      this.inputSequence++;
+    }
+    if (event) {
+      sendEvent(this.socket, event);
+    }
+    this.input = input;
+  };
+
+  /**
+   * Follows the next player when a spectator clicks, and sends a spectate
+   * event to the server.
+   */
+  private handleSpectate = () => {
+    const playerIds = Object.keys(this.entities)
+      .filter(id => this.entities[id] instanceof Player)
+      .sort();
+
+    const [input, event] = convertInputToSpectateEvent(
+      this.input,
+      playerIds,
+      this.followedId,
+    );
     if (event) {
+      this.followedId = event.spectateEventData!.targetId;
       sendEvent(this.socket, event);
     }
     this.input = input;
@@ -228,6 +354,8 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
 
     this.delta.updated = [];
     this.delta.removed = [];
+    this.delta.changed = [];
+    this.delta.hidden = [];
 
     const clientPlayer = this.getClientPlayer();
     if (clientPlayer) {
@@ -243,6 +371,13 @@ class Engine implements GraphicsGameContext, GraphicsGUIContext, UpdateContext {
     await fetchGameSnapshotData(this.host)
       .then((snapshot) => {
         syncEntities(snapshot, this);
+        if (snapshot) {
+          this.worldConfig = {
+            size: snapshot.worldSize,
+            boundary: snapshot.boundary,
+            zoneSize: snapshot.zoneSize,
+          };
+        }
       });
   };
 };
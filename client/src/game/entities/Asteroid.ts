@@ -1,6 +1,6 @@
 import p5 from "p5";
 
-import type { EntityData } from "../../pb/entities";
+import type { EntityData, EntityDelta } from "../../pb/entities";
 import type { Vector } from "../../pb/vector";
 import Audiosheet from "../audio/audio";
 import { generateExplosionAnimation } from "../graphics/animation";
@@ -34,6 +34,15 @@ class Asteroid implements Entity {
     this.rotation = data.rotation;
   };
 
+  patch = (delta: EntityDelta) => {
+    if (delta.position) {
+      this.position = delta.position;
+    }
+    if (delta.rotation !== undefined) {
+      this.rotation = delta.rotation;
+    }
+  };
+
   onRemove = () => {
     Audiosheet.get("explosionBig")?.play();
     return generateExplosionAnimation("explosionBig", this.position);
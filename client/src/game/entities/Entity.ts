@@ -1,6 +1,6 @@
 import p5 from "p5";
 
-import type { EntityData } from "../../pb/entities";
+import type { EntityData, EntityDelta } from "../../pb/entities";
 import type { Vector } from "../../pb/vector";
 import type { AnimationStep } from "../graphics/animation";
 
@@ -17,6 +17,12 @@ export interface Entity {
      */
     update: (data: EntityData) => void;
 
+    /**
+     * Updates the entity's state with the fields present in delta.
+     * @param delta incoming changes
+     */
+    patch: (delta: EntityDelta) => void;
+
     /**
      * Handles effects (e.g. audio) associated with removing the entity.
      * Should only be called if the entity is on screen.
@@ -1,6 +1,6 @@
 import type p5 from "p5";
 
-import type { EntityData } from "../../pb/entities";
+import type { AbilityTimer, EntityData, EntityDelta } from "../../pb/entities";
 import type { Vector } from "../../pb/vector";
 import Audiosheet from "../audio/audio";
 import { generateExplosionAnimation } from "../graphics/animation";
@@ -9,16 +9,32 @@ import { type AbilityFlag, isAbilityActive, SHIELD_ABILITY_FLAG } from "../logic
 import type { Entity } from "./Entity";
 
 export const PLAYER_MAX_SPEED = 20.0;
+const PLAYER_MAX_HEALTH = 100;
+export const PLAYER_MAX_HEAT = 100;
 const PLAYER_WIDTH = 80;
 
+export const TEAM_COLORS: Record<number, string> = {
+  1: "#ec1f26",
+  2: "#3b82f6",
+};
+
 class Player implements Entity {
   position: Vector;
   velocity: Vector;
   rotation: number;
   flags: AbilityFlag;
+  abilities: AbilityTimer[];
+  abilitiesReceivedAt: number; // time in ms when the ability timers were received
+
+  health: number;
+  isInvulnerable: boolean;
+  heat: number;
+  isOverheated: boolean;
+  fireInterval: number; // min ticks between shots
 
   username: string;
   score: number;
+  team: number;
   previousPositions: Vector[];
 
   sprite: p5.Image | null;
@@ -38,6 +54,14 @@ class Player implements Entity {
     this.username = playerData.username;
     this.score = playerData.score;
     this.flags = playerData.flags;
+    this.abilities = playerData.abilities;
+    this.abilitiesReceivedAt = Date.now();
+    this.health = playerData.health;
+    this.isInvulnerable = playerData.isInvulnerable;
+    this.heat = playerData.heat;
+    this.isOverheated = playerData.isOverheated;
+    this.fireInterval = playerData.fireInterval;
+    this.team = playerData.team;
 
     this.previousPositions = [];
 
@@ -59,6 +83,54 @@ class Player implements Entity {
         Audiosheet.get("score")?.play();
       }
       this.flags = playerData.flags;
+      this.abilities = playerData.abilities;
+      this.abilitiesReceivedAt = Date.now();
+      this.health = playerData.health;
+      this.isInvulnerable = playerData.isInvulnerable;
+      this.heat = playerData.heat;
+      this.isOverheated = playerData.isOverheated;
+      this.fireInterval = playerData.fireInterval;
+      this.team = playerData.team;
+    }
+  };
+
+  patch = (delta: EntityDelta) => {
+    if (delta.position) {
+      this.position = delta.position;
+    }
+    if (delta.velocity) {
+      this.velocity = delta.velocity;
+    }
+    if (delta.rotation !== undefined) {
+      this.rotation = delta.rotation;
+    }
+    if (delta.score !== undefined) {
+      if (this.score < delta.score) {
+        Audiosheet.get("score")?.play();
+      }
+      this.score = delta.score;
+    }
+    if (delta.flags !== undefined) {
+      this.flags = delta.flags;
+    }
+    if (delta.health !== undefined) {
+      this.health = delta.health;
+    }
+    if (delta.isInvulnerable !== undefined) {
+      this.isInvulnerable = delta.isInvulnerable;
+    }
+    if (delta.heat !== undefined) {
+      this.heat = delta.heat;
+    }
+    if (delta.isOverheated !== undefined) {
+      this.isOverheated = delta.isOverheated;
+    }
+    if (delta.fireInterval !== undefined) {
+      this.fireInterval = delta.fireInterval;
+    }
+    if (delta.abilities) {
+      this.abilities = delta.abilities.timers;
+      this.abilitiesReceivedAt = Date.now();
     }
   };
 
@@ -70,13 +142,14 @@ class Player implements Entity {
   draw = (instance: p5, debug?: boolean) => {
     this.drawModel(instance);
     this.drawUsername(instance);
+    this.drawHealth(instance);
     if (debug) {
       this.drawDebug(instance);
     }
   };
 
   drawIcon = (instance: p5) => {
-    instance.fill("#ff0000");
+    instance.fill(TEAM_COLORS[this.team] ?? "#ff0000");
     instance.circle(0, 0, 8);
   };
 
@@ -87,6 +160,10 @@ class Player implements Entity {
     if (!this.sprite) {
       return;
     }
+    // Blink while invulnerable.
+    if (this.isInvulnerable && Math.floor(Date.now() / 100) % 2 === 0) {
+      return;
+    }
 
     instance.push();
     instance.translate(this.position.x, this.position.y);
@@ -110,7 +187,7 @@ class Player implements Entity {
     instance.push();
     instance.translate(this.position.x, this.position.y);
     instance.noFill();
-    instance.stroke("#ffffff");
+    instance.stroke(TEAM_COLORS[this.team] ?? "#ffffff");
     instance.strokeWeight(1);
     instance.textAlign(instance.CENTER);
     instance.textFont("Courier New");
@@ -118,6 +195,18 @@ class Player implements Entity {
     instance.pop();
   };
 
+  private drawHealth = (instance: p5) => {
+    const width = PLAYER_WIDTH * this.health / PLAYER_MAX_HEALTH;
+    instance.push();
+    instance.translate(this.position.x - PLAYER_WIDTH / 2, this.position.y - 68);
+    instance.noStroke();
+    instance.fill("#ffffff22");
+    instance.rect(0, 0, PLAYER_WIDTH, 4);
+    instance.fill(this.health > PLAYER_MAX_HEALTH / 3 ? "#29cc49" : "#ec1f26");
+    instance.rect(0, 0, width, 4);
+    instance.pop();
+  };
+
   private drawDebug = (instance: p5) => {
     instance.push();
     instance.translate(this.position.x, this.position.y);
@@ -1,10 +1,10 @@
 import p5 from "p5";
 
-import type { EntityData } from "../../pb/entities";
+import type { EntityData, EntityDelta } from "../../pb/entities";
 import type { Vector } from "../../pb/vector";
 import Audiosheet from "../audio/audio";
 import Spritesheet from "../graphics/sprites";
-import { type AbilityFlag, MULTISHOT_ABILITY_FLAG, SHIELD_ABILITY_FLAG, toAbilityName, WIDE_BEAM_ABILITY_FLAG } from "../logic/abilities";
+import { ABILITY_COLORS, type AbilityFlag, toAbilityName } from "../logic/abilities";
 import type { Entity } from "./Entity";
 
 const POWERUP_WIDTH = 80;
@@ -40,6 +40,15 @@ class Powerup implements Entity {
     this.rotation = data.rotation;
   };
 
+  patch = (delta: EntityDelta) => {
+    if (delta.position) {
+      this.position = delta.position;
+    }
+    if (delta.rotation !== undefined) {
+      this.rotation = delta.rotation;
+    }
+  };
+
   onRemove = () => {
     Audiosheet.get("pickup")?.play();
     return null;
@@ -64,6 +73,7 @@ class Powerup implements Entity {
       this.sprite = Spritesheet.get(this.spriteName());
     }
     if (!this.sprite) {
+      this.drawFallback(instance);
       return;
     }
 
@@ -74,6 +84,17 @@ class Powerup implements Entity {
     instance.pop();
   };
 
+  // Abilities without a sprite are drawn as a ring in the ability's color.
+  private drawFallback = (instance: p5) => {
+    instance.push();
+    instance.translate(this.position.x, this.position.y);
+    instance.stroke(this.iconFill());
+    instance.strokeWeight(6);
+    instance.fill("#111111");
+    instance.circle(0, 0, POWERUP_WIDTH * 0.8);
+    instance.pop();
+  };
+
   private drawDebug = (instance: p5) => {
     instance.push();
     instance.translate(this.position.x, this.position.y);
@@ -92,16 +113,11 @@ class Powerup implements Entity {
   };
 
   private iconFill = () => {
-    switch (this.ability) {
-    case MULTISHOT_ABILITY_FLAG:
-      return "#fac811";
-    case WIDE_BEAM_ABILITY_FLAG:
-      return "#f073ff";
-    case SHIELD_ABILITY_FLAG:
-      return "#36d9b0";
-    default:
+    const fill = ABILITY_COLORS[this.ability];
+    if (!fill) {
       throw new TypeError("invalid flags");
     }
+    return fill;
   };
 }
 
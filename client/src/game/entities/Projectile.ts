@@ -1,10 +1,10 @@
 import p5 from "p5";
 
-import type { EntityData } from "../../pb/entities";
+import type { EntityData, EntityDelta } from "../../pb/entities";
 import type { Vector } from "../../pb/vector";
 import Audiosheet from "../audio/audio";
 import { generateExplosionAnimation } from "../graphics/animation";
-import { type AbilityFlag, isAbilityActive, WIDE_BEAM_ABILITY_FLAG } from "../logic/abilities";
+import { ABILITY_COLORS, type AbilityFlag, HOMING_ABILITY_FLAG, isAbilityActive, PIERCING_ABILITY_FLAG, WIDE_BEAM_ABILITY_FLAG } from "../logic/abilities";
 import type { Entity } from "./Entity";
 
 const PROJECTILE_WIDTH = 20;
@@ -43,6 +43,15 @@ class Projectile implements Entity {
     }
   };
 
+  patch = (delta: EntityDelta) => {
+    if (delta.position) {
+      this.position = delta.position;
+    }
+    if (delta.rotation !== undefined) {
+      this.rotation = delta.rotation;
+    }
+  };
+
   onRemove = () => {
     Audiosheet.get("explosionSmall")?.play();
     return generateExplosionAnimation("explosionSmall", this.position);
@@ -60,14 +69,24 @@ class Projectile implements Entity {
     instance.translate(this.position.x, this.position.y);
     instance.rotate(this.rotation);
 
-    if (isAbilityActive(this.flags, WIDE_BEAM_ABILITY_FLAG)) {
+    const fill = isAbilityActive(this.flags, HOMING_ABILITY_FLAG)
+      ? ABILITY_COLORS[HOMING_ABILITY_FLAG]
+      : "#ffffff";
+
+    if (isAbilityActive(this.flags, PIERCING_ABILITY_FLAG)) {
+      instance.noStroke();
+      instance.fill(ABILITY_COLORS[PIERCING_ABILITY_FLAG]);
+      instance.rect(-50, -4, 60, 8);
+      instance.fill(fill);
+      instance.rect(-40, -2, 50, 4);
+    } else if (isAbilityActive(this.flags, WIDE_BEAM_ABILITY_FLAG)) {
       instance.noFill();
-      instance.stroke("#ffffff");
+      instance.stroke(fill);
       instance.strokeWeight(8);
       instance.arc(-40, 0, 60, 80, 3 / 2 * Math.PI + 0.5, 1 / 2 * Math.PI - 0.5);
     } else {
       instance.noStroke();
-      instance.fill("#ffffff");
+      instance.fill(fill);
       instance.circle(-20, 0, 10);
       instance.rect(-20, -5, 20, 10);
       instance.circle(0, 0, 10);
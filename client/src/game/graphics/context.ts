@@ -1,5 +1,8 @@
 import type p5 from "p5";
 
+import type { DamageCause } from "../../pb/entities";
+import { Boundary, type Event_RoundEventData } from "../../pb/event";
+
 import type { EntityMap } from "../entities/Entity";
 import type Player from "../entities/Player";
 import type { Input } from "../logic/input";
@@ -18,6 +21,27 @@ export function initCanvasConfig(): CanvasConfig {
   };
 }
 
+export type WorldConfig = {
+  size: number;
+  boundary: Boundary;
+  zoneSize: number; // or 0 if there is no zone
+};
+
+export function initWorldConfig(): WorldConfig {
+  return {
+    size: 0,
+    boundary: Boundary.BOUNDARY_WRAP,
+    zoneSize: 0,
+  };
+}
+
+export type KillFeedEntry = {
+  killer: string | null; // or null if no player caused the death
+  victim: string;
+  cause: DamageCause;
+  receivedAt: number; // time in ms
+};
+
 /**
  * The context to draw.
  * Contains a subset of fields from Engine to avoid passing the whole Engine.
@@ -26,6 +50,7 @@ export interface GraphicsGameContext {
   instance: p5;
   entities: EntityMap;
   canvasConfig: CanvasConfig;
+  worldConfig: WorldConfig;
 }
 
 /**
@@ -36,6 +61,9 @@ export interface GraphicsGUIContext {
   instance: p5;
   entities: EntityMap;
   canvasConfig: CanvasConfig;
+  tick: number;
   getClientPlayer: () => Player | null;
   getInput: () => Input;
+  getRound: () => Event_RoundEventData | null;
+  getKillFeed: () => KillFeedEntry[];
 }
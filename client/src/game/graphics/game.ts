@@ -1,5 +1,6 @@
+import { Boundary } from "../../pb/event";
 import Player, { PLAYER_MAX_SPEED } from "../entities/Player";
-import { shouldCullEntity } from "../logic/update";
+import { getNearestCopy, shouldCullEntity } from "../logic/update";
 import type { AnimationStep } from "./animation";
 import type { CanvasConfig, GraphicsGameContext } from "./context";
 
@@ -13,7 +14,7 @@ export function updateCanvasConfig(config: CanvasConfig, clientPlayer: Player) {
   const speed = Math.hypot(clientPlayer.velocity.x, clientPlayer.velocity.y);
   config.x = clientPlayer.position.x;
   config.y = clientPlayer.position.y;
-  config.zoom = MAXIMUM_ZOOM - (speed / PLAYER_MAX_SPEED) * (MAXIMUM_ZOOM - MINIMUM_ZOOM);
+  config.zoom = MAXIMUM_ZOOM - Math.min(speed / PLAYER_MAX_SPEED, 1) * (MAXIMUM_ZOOM - MINIMUM_ZOOM);
 }
 
 export function centerCanvas(context: GraphicsGameContext) {
@@ -50,10 +51,46 @@ export function drawBackground(context: GraphicsGameContext) {
   instance.pop();
 }
 
+/**
+ * Draws the edges of the world, and the safe zone if there is one. Walls are
+ * drawn brighter than the seams of a wrapped world.
+ */
+export function drawWorldEdge(context: GraphicsGameContext) {
+  const { instance, worldConfig } = context;
+  if (worldConfig.size === 0) {
+    return;
+  }
+
+  instance.push();
+  instance.noFill();
+  instance.strokeWeight(8);
+  const isWrapped = worldConfig.boundary === Boundary.BOUNDARY_WRAP;
+  instance.stroke(isWrapped ? "#ffffff44" : "#ffffffcc");
+  instance.rect(0, 0, worldConfig.size, worldConfig.size);
+
+  if (worldConfig.zoneSize > 0) {
+    const offset = (worldConfig.size - worldConfig.zoneSize) / 2;
+    instance.stroke("#ff4444cc");
+    instance.rect(offset, offset, worldConfig.zoneSize, worldConfig.zoneSize);
+  }
+  instance.pop();
+}
+
+/**
+ * Draws the entities near the canvas. In a wrapped world, entities across a
+ * seam are drawn where they appear on this side of it.
+ */
 export function drawEntities(context: GraphicsGameContext) {
+  const { instance, canvasConfig, worldConfig } = context;
   Object.values(context.entities)
-    .filter(entity => !shouldCullEntity(entity.position, context.canvasConfig))
-    .forEach(entity => entity.draw(context.instance, DEBUG));
+    .filter(entity => !shouldCullEntity(entity.position, canvasConfig, worldConfig))
+    .forEach(entity => {
+      const nearest = getNearestCopy(entity.position, canvasConfig, worldConfig);
+      instance.push();
+      instance.translate(nearest.x - entity.position.x, nearest.y - entity.position.y);
+      entity.draw(instance, DEBUG);
+      instance.pop();
+    });
 }
 
 export function drawAnimations(
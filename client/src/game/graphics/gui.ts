@@ -1,10 +1,16 @@
 
-import { PLAYER_MAX_SPEED } from "../entities/Player";
-import type { GraphicsGUIContext } from "./context";
+import { DamageCause } from "../../pb/entities";
+import { type Event_RoundEventData, GameMode } from "../../pb/event";
+import { PLAYER_MAX_HEAT, PLAYER_MAX_SPEED, TEAM_COLORS } from "../entities/Player";
+import { ABILITY_COLORS, toAbilityName } from "../logic/abilities";
+import type { GraphicsGUIContext, KillFeedEntry } from "./context";
 
 const MINIMAP_RADIUS = 100;
 const MINIMAP_OFFSET = 128;
 const MINIMAP_SCALE = 1 / 800;
+const TICK_RATE = 60; // server ticks per second
+const SCOREBOARD_SIZE = 8;
+const KILL_FEED_DURATION = 5000; // ms to show each death
 
 export function drawMinimap(context: GraphicsGUIContext) {
   const { instance, entities, canvasConfig, getClientPlayer } = context;
@@ -48,6 +54,8 @@ export function drawMinimap(context: GraphicsGUIContext) {
 export function drawHUD(context: GraphicsGUIContext) {
   drawSpeedometer(context);
   drawScore(context);
+  drawAbilities(context);
+  drawHeat(context);
 }
 
 export function drawSpeedometer(context: GraphicsGUIContext) {
@@ -86,7 +94,7 @@ export function drawSpeedometer(context: GraphicsGUIContext) {
   instance.strokeWeight(12);
   instance.strokeCap(instance.SQUARE);
   instance.noFill();
-  for (let i = 0; i < speed / PLAYER_MAX_SPEED * 16; i++) {
+  for (let i = 0; i < Math.min(speed / PLAYER_MAX_SPEED, 1) * 16; i++) {
     instance.stroke(i >= 12 ? "#ec1f26" : "#29cc49");
     instance.arc(0, 0, 248, 248, start + ((interval + gap) * i), start + (interval * (i + 1)) + gap * i);
   }
@@ -112,6 +120,81 @@ export function drawScore(context: GraphicsGUIContext) {
   instance.pop();
 }
 
+export function drawHeat(context: GraphicsGUIContext) {
+  const { instance, getClientPlayer } = context;
+  const clientPlayer = getClientPlayer();
+  if (!clientPlayer) {
+    return;
+  }
+
+  const width = 160;
+  instance.push();
+  instance.translate(window.innerWidth - 280 - width / 2, window.innerHeight - 28);
+  instance.noStroke();
+  instance.fill("#ffffff22");
+  instance.rect(0, 0, width, 6);
+  instance.fill(clientPlayer.isOverheated ? "#ec1f26" : "#fac811");
+  instance.rect(0, 0, width * clientPlayer.heat / PLAYER_MAX_HEAT, 6);
+  instance.pop();
+}
+
+export function drawAbilities(context: GraphicsGUIContext) {
+  const { instance, getClientPlayer } = context;
+  const clientPlayer = getClientPlayer();
+  if (!clientPlayer) {
+    return;
+  }
+
+  const elapsed = (Date.now() - clientPlayer.abilitiesReceivedAt) / 1000;
+  instance.push();
+  instance.translate(window.innerWidth - 280, window.innerHeight - 64);
+  instance.textFont("Courier New");
+  instance.textAlign(instance.CENTER);
+  clientPlayer.abilities.forEach((timer, i) => {
+    const remaining = Math.max(timer.remaining / TICK_RATE - elapsed, 0);
+    const stacks = timer.stacks > 1 ? ` x${timer.stacks}` : "";
+    instance.stroke(ABILITY_COLORS[timer.ability] ?? "#ffffff");
+    instance.fill(ABILITY_COLORS[timer.ability] ?? "#ffffff");
+    instance.text(`${toAbilityName(timer.ability) ?? "?"}${stacks} ${remaining.toFixed(1)}s`, 0, -i * 20);
+  });
+  instance.pop();
+}
+
+export function drawKillFeed(context: GraphicsGUIContext) {
+  const { instance, getKillFeed } = context;
+  const now = Date.now();
+
+  instance.push();
+  instance.translate(24, 32);
+  instance.textFont("Courier New");
+  instance.textSize(14);
+  instance.stroke("#ffffff");
+  instance.fill("#ffffff");
+  getKillFeed()
+    .filter(entry => now - entry.receivedAt < KILL_FEED_DURATION)
+    .forEach((entry, i) => {
+      instance.text(getKillFeedText(entry), 0, i * 20);
+    });
+  instance.pop();
+}
+
+function getKillFeedText(entry: KillFeedEntry) {
+  switch (entry.cause) {
+  case DamageCause.DAMAGE_CAUSE_PROJECTILE:
+    return `${entry.killer ?? "?"} shot ${entry.victim}`;
+  case DamageCause.DAMAGE_CAUSE_WIDE_BEAM:
+    return `${entry.killer ?? "?"} beamed ${entry.victim}`;
+  case DamageCause.DAMAGE_CAUSE_PLAYER:
+    return `${entry.killer ?? "?"} rammed ${entry.victim}`;
+  case DamageCause.DAMAGE_CAUSE_ASTEROID:
+    return `${entry.victim} hit an asteroid`;
+  case DamageCause.DAMAGE_CAUSE_ZONE:
+    return `${entry.victim} was caught outside the zone`;
+  default:
+    return `${entry.victim} died`;
+  }
+}
+
 export function drawRespawnPrompt(context: GraphicsGUIContext) {
   const { instance, getClientPlayer } = context;
   const clientPlayer = getClientPlayer();
@@ -141,3 +224,60 @@ export function drawRespawnPrompt(context: GraphicsGUIContext) {
 
   instance.pop();
 }
+
+export function drawRound(context: GraphicsGUIContext) {
+  const { instance, tick, getRound } = context;
+  const round = getRound();
+  if (!round) {
+    return;
+  }
+
+  instance.push();
+  instance.textFont("Courier New");
+  instance.textAlign(instance.CENTER);
+  instance.stroke("#ffffff");
+  instance.fill("#ffffff");
+
+  if (!round.isOver) {
+    if (round.duration > 0) {
+      const remaining = Math.max(round.duration - (tick - round.startTick), 0) / TICK_RATE;
+      const minutes = Math.floor(remaining / 60);
+      const seconds = `${Math.floor(remaining % 60)}`.padStart(2, "0");
+      instance.textSize(16);
+      instance.text(`round ${round.round} - ${minutes}:${seconds}`, window.innerWidth / 2, 32);
+    }
+    instance.pop();
+    return;
+  }
+
+  instance.translate(window.innerWidth / 2, window.innerHeight / 3);
+  instance.textSize(32);
+  instance.text(getWinnerText(round), 0, 0);
+
+  instance.textSize(16);
+  round.scores
+    .slice(0, SCOREBOARD_SIZE)
+    .forEach((score, i) => {
+      instance.stroke(TEAM_COLORS[score.team] ?? "#ffffff");
+      instance.fill(TEAM_COLORS[score.team] ?? "#ffffff");
+      instance.text(`${score.username.padEnd(24)}${`${score.kills}`.padStart(4)}`, 0, 48 + i * 24);
+    });
+
+  instance.pop();
+}
+
+function getWinnerText(round: Event_RoundEventData) {
+  if (round.mode === GameMode.GAME_MODE_TEAM_DEATHMATCH) {
+    switch (round.winnerTeam) {
+    case 1:
+      return "red team wins!";
+    case 2:
+      return "blue team wins!";
+    default:
+      return "draw!";
+    }
+  }
+
+  const winner = round.scores.find(score => score.id === round.winnerId);
+  return winner ? `${winner.username} wins!` : "round over!";
+}
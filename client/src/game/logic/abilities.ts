@@ -3,6 +3,20 @@ export type AbilityFlag = number;
 export const MULTISHOT_ABILITY_FLAG: AbilityFlag = 1 << 1;
 export const WIDE_BEAM_ABILITY_FLAG: AbilityFlag = 1 << 2;
 export const SHIELD_ABILITY_FLAG: AbilityFlag = 1 << 3;
+export const SPEED_BOOST_ABILITY_FLAG: AbilityFlag = 1 << 4;
+export const HOMING_ABILITY_FLAG: AbilityFlag = 1 << 5;
+export const RAPID_FIRE_ABILITY_FLAG: AbilityFlag = 1 << 6;
+export const PIERCING_ABILITY_FLAG: AbilityFlag = 1 << 7;
+
+export const ABILITY_COLORS: Record<AbilityFlag, string> = {
+  [MULTISHOT_ABILITY_FLAG]: "#fac811",
+  [WIDE_BEAM_ABILITY_FLAG]: "#f073ff",
+  [SHIELD_ABILITY_FLAG]: "#36d9b0",
+  [SPEED_BOOST_ABILITY_FLAG]: "#29cc49",
+  [HOMING_ABILITY_FLAG]: "#ff8a1f",
+  [RAPID_FIRE_ABILITY_FLAG]: "#ec1f26",
+  [PIERCING_ABILITY_FLAG]: "#3b82f6",
+};
 
 /**
  * Reports whether an ability is active.
@@ -29,6 +43,14 @@ export function toAbilityName(flag: AbilityFlag): string | null {
     return "wide";
   case SHIELD_ABILITY_FLAG:
     return "shield";
+  case SPEED_BOOST_ABILITY_FLAG:
+    return "speed";
+  case HOMING_ABILITY_FLAG:
+    return "homing";
+  case RAPID_FIRE_ABILITY_FLAG:
+    return "rapid";
+  case PIERCING_ABILITY_FLAG:
+    return "piercing";
   default:
     return null;
   }
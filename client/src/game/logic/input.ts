@@ -67,12 +67,18 @@ export function handleMouseMove(
  * Converts the input into an Event for serialization.
  * Also resets the input.
  * @param current current input
+ * @param clientId id of the client
+ * @param isRespawn whether the input should be sent as a respawn request
+ * @param sequence sequence number of the input
+ * @param tick latest server tick received by the client
  * @returns new input and the converted event
  */
 export function convertInputToEvent(
   current: Input,
   clientId: string,
   isRespawn: boolean,
+  sequence: number,
+  tick: number,
 ): [Input, Event | null] {
   if (isRespawn) {
     return [
@@ -93,6 +99,38 @@ export function convertInputToEvent(
       inputEventData: {
         id: clientId,
         ...current,
+        sequence,
+        tick,
+      },
+    },
+  ];
+}
+
+/**
+ * Converts a click from a spectator into an Event which follows the next
+ * player. Also resets the input.
+ * @param current current input
+ * @param playerIds ids of the players which can be followed, in order
+ * @param followedId id of the currently followed player
+ * @returns new input and the converted event
+ */
+export function convertInputToSpectateEvent(
+  current: Input,
+  playerIds: string[],
+  followedId: string | null,
+): [Input, Event | null] {
+  if (!current.mousePressed || playerIds.length === 0) {
+    return [initInput(), null];
+  }
+
+  const index = followedId ? playerIds.indexOf(followedId) : -1;
+  const targetId = playerIds[(index + 1) % playerIds.length];
+  return [
+    initInput(),
+    {
+      type: EventType.EVENT_TYPE_SPECTATE,
+      spectateEventData: {
+        targetId,
       },
     },
   ];
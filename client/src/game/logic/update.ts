@@ -1,7 +1,8 @@
-import { EntityData, EntityType } from "../../pb/entities";
-import type {
-  Event_DeltaEventData,
-  Event_SnapshotEventData,
+import { EntityData, EntityDelta, EntityType } from "../../pb/entities";
+import {
+  Boundary,
+  type Event_DeltaEventData,
+  type Event_SnapshotEventData,
 } from "../../pb/event";
 import type { Vector } from "../../pb/vector";
 import Asteroid from "../entities/Asteroid";
@@ -13,7 +14,7 @@ import {
   type AnimationStep,
   generatePlayerTrailAnimation,
 } from "../graphics/animation";
-import type { CanvasConfig } from "../graphics/context";
+import type { CanvasConfig, WorldConfig } from "../graphics/context";
 
 /**
  * The context to update.
@@ -23,6 +24,7 @@ export interface UpdateContext {
   delta: Event_DeltaEventData;
   entities: EntityMap;
   canvasConfig: CanvasConfig;
+  worldConfig: WorldConfig;
   addAnimation: (animation: AnimationStep, isForeground: boolean) => void;
 }
 
@@ -47,6 +49,11 @@ export function initDelta(): Event_DeltaEventData {
     timestamp: 0,
     updated: [],
     removed: [],
+    changed: [],
+    hidden: [],
+    tick: 0,
+    inputSequence: 0,
+    zoneSize: 0,
   };
 }
 
@@ -71,8 +78,12 @@ export function mergeDeltas(
       current.updated.push(entity);
     });
 
+  current.changed = [...current.changed, ...next.changed];
+  current.hidden = [...current.hidden, ...next.hidden];
   current.removed = [...current.removed, ...next.removed];
   current.timestamp = Math.max(current.timestamp, next.timestamp);
+  current.tick = Math.max(current.tick, next.tick);
+  current.inputSequence = Math.max(current.inputSequence, next.inputSequence);
   return current;
 }
 
@@ -82,7 +93,7 @@ export function mergeDeltas(
  * @param context the context to update
  */
 export function removeEntities(context: UpdateContext) {
-  const { delta, entities, canvasConfig, addAnimation } = context;
+  const { delta, entities, canvasConfig, worldConfig, addAnimation } = context;
   delta.removed
     .forEach(id => {
       const entity = entities[id];
@@ -90,7 +101,7 @@ export function removeEntities(context: UpdateContext) {
         return;
       }
 
-      if (!shouldCullEntity(entity.position, canvasConfig)) {
+      if (!shouldCullEntity(entity.position, canvasConfig, worldConfig)) {
         const animation = entity.onRemove();
         if (animation) {
           addAnimation(animation, true);
@@ -98,6 +109,11 @@ export function removeEntities(context: UpdateContext) {
       }
       delete entities[id];
     });
+
+  // Hidden entities have only left the client's view, so they are dropped
+  // without any removal effects.
+  delta.hidden
+    .forEach(id => delete entities[id]);
 }
 
 /**
@@ -108,7 +124,11 @@ export function updateEntities(context: UpdateContext) {
   const { delta } = context;
   delta.updated
     .filter(entityData => !delta.removed.includes(entityData.id))
+    .filter(entityData => !delta.hidden.includes(entityData.id))
     .forEach(entityData => handleEntityData(entityData, context));
+  delta.changed
+    .filter(entityDelta => !delta.removed.includes(entityDelta.id))
+    .forEach(entityDelta => handleEntityDelta(entityDelta, context));
 }
 
 /**
@@ -119,10 +139,10 @@ export function updateEntities(context: UpdateContext) {
  */
 export function handleEntityData(data: EntityData, context: UpdateContext) {
   const { id, type } = data;
-  const { entities, canvasConfig, addAnimation } = context;
+  const { entities, canvasConfig, worldConfig, addAnimation } = context;
 
   if (entities[id]) {
-    if (shouldCullEntity(entities[id].position, canvasConfig)) {
+    if (shouldCullEntity(entities[id].position, canvasConfig, worldConfig)) {
       return;
     }
     entities[id].update(data);
@@ -159,16 +179,53 @@ export function handleEntityData(data: EntityData, context: UpdateContext) {
   }
 }
 
+/**
+ * Applies the changed fields in delta to an existing entity.
+ * Deltas for unknown entities are dropped, since the server periodically
+ * sends every entity in full.
+ * @param delta changed fields
+ * @param context the context to update
+ */
+export function handleEntityDelta(delta: EntityDelta, context: UpdateContext) {
+  const { entities } = context;
+  entities[delta.id]?.patch(delta);
+}
+
 /**
  * Check if an entity should be updated/drawn.
  * Uses the window height and screen as boundaries.
  * @param position location of the entity
  * @param canvasConfig canvas origin
+ * @param worldConfig edges of the world
  */
 export function shouldCullEntity(
   position: Vector,
   canvasConfig: CanvasConfig,
+  worldConfig: WorldConfig,
 ): boolean {
-  return Math.abs(canvasConfig.x - position.x) > window.innerWidth
-    || Math.abs(canvasConfig.y - position.y) > window.innerHeight;
+  const nearest = getNearestCopy(position, canvasConfig, worldConfig);
+  return Math.abs(canvasConfig.x - nearest.x) > window.innerWidth
+    || Math.abs(canvasConfig.y - nearest.y) > window.innerHeight;
+}
+
+/**
+ * Finds where an entity appears closest to the canvas origin. In a wrapped
+ * world this may be a copy across the seam, otherwise it is the position.
+ * @param position location of the entity
+ * @param canvasConfig canvas origin
+ * @param worldConfig edges of the world
+ */
+export function getNearestCopy(
+  position: Vector,
+  canvasConfig: CanvasConfig,
+  worldConfig: WorldConfig,
+): Vector {
+  const { size, boundary } = worldConfig;
+  if (size === 0 || boundary !== Boundary.BOUNDARY_WRAP) {
+    return position;
+  }
+  return {
+    x: position.x + Math.round((canvasConfig.x - position.x) / size) * size,
+    y: position.y + Math.round((canvasConfig.y - position.y) / size) * size,
+  };
 }
@@ -10,6 +10,63 @@ import { Vector } from "./vector";
 
 export const protobufPackage = "dogfight";
 
+export enum DamageCause {
+  DAMAGE_CAUSE_UNKNOWN = 0,
+  DAMAGE_CAUSE_PROJECTILE = 1,
+  DAMAGE_CAUSE_WIDE_BEAM = 2,
+  DAMAGE_CAUSE_ASTEROID = 3,
+  DAMAGE_CAUSE_PLAYER = 4,
+  DAMAGE_CAUSE_ZONE = 5,
+  UNRECOGNIZED = -1,
+}
+
+export function damageCauseFromJSON(object: any): DamageCause {
+  switch (object) {
+    case 0:
+    case "DAMAGE_CAUSE_UNKNOWN":
+      return DamageCause.DAMAGE_CAUSE_UNKNOWN;
+    case 1:
+    case "DAMAGE_CAUSE_PROJECTILE":
+      return DamageCause.DAMAGE_CAUSE_PROJECTILE;
+    case 2:
+    case "DAMAGE_CAUSE_WIDE_BEAM":
+      return DamageCause.DAMAGE_CAUSE_WIDE_BEAM;
+    case 3:
+    case "DAMAGE_CAUSE_ASTEROID":
+      return DamageCause.DAMAGE_CAUSE_ASTEROID;
+    case 4:
+    case "DAMAGE_CAUSE_PLAYER":
+      return DamageCause.DAMAGE_CAUSE_PLAYER;
+    case 5:
+    case "DAMAGE_CAUSE_ZONE":
+      return DamageCause.DAMAGE_CAUSE_ZONE;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return DamageCause.UNRECOGNIZED;
+  }
+}
+
+export function damageCauseToJSON(object: DamageCause): string {
+  switch (object) {
+    case DamageCause.DAMAGE_CAUSE_UNKNOWN:
+      return "DAMAGE_CAUSE_UNKNOWN";
+    case DamageCause.DAMAGE_CAUSE_PROJECTILE:
+      return "DAMAGE_CAUSE_PROJECTILE";
+    case DamageCause.DAMAGE_CAUSE_WIDE_BEAM:
+      return "DAMAGE_CAUSE_WIDE_BEAM";
+    case DamageCause.DAMAGE_CAUSE_ASTEROID:
+      return "DAMAGE_CAUSE_ASTEROID";
+    case DamageCause.DAMAGE_CAUSE_PLAYER:
+      return "DAMAGE_CAUSE_PLAYER";
+    case DamageCause.DAMAGE_CAUSE_ZONE:
+      return "DAMAGE_CAUSE_ZONE";
+    case DamageCause.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
 export enum EntityType {
   ENTITY_TYPE_UNKNOWN = 0,
   ENTITY_TYPE_ASTEROID = 1,
@@ -87,6 +144,13 @@ export interface EntityData_PlayerData {
   username: string;
   score: number;
   flags: number;
+  team: number;
+  abilities: AbilityTimer[];
+  health: number;
+  isInvulnerable: boolean;
+  heat: number;
+  isOverheated: boolean;
+  fireInterval: number;
 }
 
 export interface EntityData_PowerupData {
@@ -98,6 +162,31 @@ export interface EntityData_ProjectileData {
   lifetime: number;
 }
 
+export interface AbilityTimer {
+  ability: number;
+  remaining: number;
+  stacks: number;
+}
+
+export interface AbilityTimers {
+  timers: AbilityTimer[];
+}
+
+export interface EntityDelta {
+  id: string;
+  position: Vector | undefined;
+  velocity: Vector | undefined;
+  rotation?: number | undefined;
+  score?: number | undefined;
+  flags?: number | undefined;
+  abilities: AbilityTimers | undefined;
+  health?: number | undefined;
+  isInvulnerable?: boolean | undefined;
+  heat?: number | undefined;
+  isOverheated?: boolean | undefined;
+  fireInterval?: number | undefined;
+}
+
 function createBaseEntityData(): EntityData {
   return {
     type: 0,
@@ -371,7 +460,18 @@ export const EntityData_AsteroidData: MessageFns<EntityData_AsteroidData> = {
 };
 
 function createBaseEntityData_PlayerData(): EntityData_PlayerData {
-  return { username: "", score: 0, flags: 0 };
+  return {
+    username: "",
+    score: 0,
+    flags: 0,
+    team: 0,
+    abilities: [],
+    health: 0,
+    isInvulnerable: false,
+    heat: 0,
+    isOverheated: false,
+    fireInterval: 0,
+  };
 }
 
 export const EntityData_PlayerData: MessageFns<EntityData_PlayerData> = {
@@ -385,6 +485,27 @@ export const EntityData_PlayerData: MessageFns<EntityData_PlayerData> = {
     if (message.flags !== 0) {
       writer.uint32(24).uint32(message.flags);
     }
+    if (message.team !== 0) {
+      writer.uint32(32).uint32(message.team);
+    }
+    for (const v of message.abilities) {
+      AbilityTimer.encode(v!, writer.uint32(42).fork()).join();
+    }
+    if (message.health !== 0) {
+      writer.uint32(48).uint32(message.health);
+    }
+    if (message.isInvulnerable !== false) {
+      writer.uint32(56).bool(message.isInvulnerable);
+    }
+    if (message.heat !== 0) {
+      writer.uint32(64).uint32(message.heat);
+    }
+    if (message.isOverheated !== false) {
+      writer.uint32(72).bool(message.isOverheated);
+    }
+    if (message.fireInterval !== 0) {
+      writer.uint32(80).uint32(message.fireInterval);
+    }
     return writer;
   },
 
@@ -419,6 +540,62 @@ export const EntityData_PlayerData: MessageFns<EntityData_PlayerData> = {
           message.flags = reader.uint32();
           continue;
         }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.team = reader.uint32();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.abilities.push(AbilityTimer.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 6: {
+          if (tag !== 48) {
+            break;
+          }
+
+          message.health = reader.uint32();
+          continue;
+        }
+        case 7: {
+          if (tag !== 56) {
+            break;
+          }
+
+          message.isInvulnerable = reader.bool();
+          continue;
+        }
+        case 8: {
+          if (tag !== 64) {
+            break;
+          }
+
+          message.heat = reader.uint32();
+          continue;
+        }
+        case 9: {
+          if (tag !== 72) {
+            break;
+          }
+
+          message.isOverheated = reader.bool();
+          continue;
+        }
+        case 10: {
+          if (tag !== 80) {
+            break;
+          }
+
+          message.fireInterval = reader.uint32();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -433,6 +610,15 @@ export const EntityData_PlayerData: MessageFns<EntityData_PlayerData> = {
       username: isSet(object.username) ? globalThis.String(object.username) : "",
       score: isSet(object.score) ? globalThis.Number(object.score) : 0,
       flags: isSet(object.flags) ? globalThis.Number(object.flags) : 0,
+      team: isSet(object.team) ? globalThis.Number(object.team) : 0,
+      abilities: globalThis.Array.isArray(object?.abilities)
+        ? object.abilities.map((e: any) => AbilityTimer.fromJSON(e))
+        : [],
+      health: isSet(object.health) ? globalThis.Number(object.health) : 0,
+      isInvulnerable: isSet(object.isInvulnerable) ? globalThis.Boolean(object.isInvulnerable) : false,
+      heat: isSet(object.heat) ? globalThis.Number(object.heat) : 0,
+      isOverheated: isSet(object.isOverheated) ? globalThis.Boolean(object.isOverheated) : false,
+      fireInterval: isSet(object.fireInterval) ? globalThis.Number(object.fireInterval) : 0,
     };
   },
 
@@ -447,6 +633,27 @@ export const EntityData_PlayerData: MessageFns<EntityData_PlayerData> = {
     if (message.flags !== 0) {
       obj.flags = Math.round(message.flags);
     }
+    if (message.team !== 0) {
+      obj.team = Math.round(message.team);
+    }
+    if (message.abilities?.length) {
+      obj.abilities = message.abilities.map((e) => AbilityTimer.toJSON(e));
+    }
+    if (message.health !== 0) {
+      obj.health = Math.round(message.health);
+    }
+    if (message.isInvulnerable !== false) {
+      obj.isInvulnerable = message.isInvulnerable;
+    }
+    if (message.heat !== 0) {
+      obj.heat = Math.round(message.heat);
+    }
+    if (message.isOverheated !== false) {
+      obj.isOverheated = message.isOverheated;
+    }
+    if (message.fireInterval !== 0) {
+      obj.fireInterval = Math.round(message.fireInterval);
+    }
     return obj;
   },
 
@@ -458,6 +665,13 @@ export const EntityData_PlayerData: MessageFns<EntityData_PlayerData> = {
     message.username = object.username ?? "";
     message.score = object.score ?? 0;
     message.flags = object.flags ?? 0;
+    message.team = object.team ?? 0;
+    message.abilities = object.abilities?.map((e) => AbilityTimer.fromPartial(e)) || [];
+    message.health = object.health ?? 0;
+    message.isInvulnerable = object.isInvulnerable ?? false;
+    message.heat = object.heat ?? 0;
+    message.isOverheated = object.isOverheated ?? false;
+    message.fireInterval = object.fireInterval ?? 0;
     return message;
   },
 };
@@ -596,6 +810,413 @@ export const EntityData_ProjectileData: MessageFns<EntityData_ProjectileData> =
   },
 };
 
+function createBaseAbilityTimer(): AbilityTimer {
+  return { ability: 0, remaining: 0, stacks: 0 };
+}
+
+export const AbilityTimer: MessageFns<AbilityTimer> = {
+  encode(message: AbilityTimer, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.ability !== 0) {
+      writer.uint32(8).uint32(message.ability);
+    }
+    if (message.remaining !== 0) {
+      writer.uint32(16).uint32(message.remaining);
+    }
+    if (message.stacks !== 0) {
+      writer.uint32(24).uint32(message.stacks);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): AbilityTimer {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAbilityTimer();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 8) {
+            break;
+          }
+
+          message.ability = reader.uint32();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.remaining = reader.uint32();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.stacks = reader.uint32();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): AbilityTimer {
+    return {
+      ability: isSet(object.ability) ? globalThis.Number(object.ability) : 0,
+      remaining: isSet(object.remaining) ? globalThis.Number(object.remaining) : 0,
+      stacks: isSet(object.stacks) ? globalThis.Number(object.stacks) : 0,
+    };
+  },
+
+  toJSON(message: AbilityTimer): unknown {
+    const obj: any = {};
+    if (message.ability !== 0) {
+      obj.ability = Math.round(message.ability);
+    }
+    if (message.remaining !== 0) {
+      obj.remaining = Math.round(message.remaining);
+    }
+    if (message.stacks !== 0) {
+      obj.stacks = Math.round(message.stacks);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<AbilityTimer>, I>>(base?: I): AbilityTimer {
+    return AbilityTimer.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<AbilityTimer>, I>>(object: I): AbilityTimer {
+    const message = createBaseAbilityTimer();
+    message.ability = object.ability ?? 0;
+    message.remaining = object.remaining ?? 0;
+    message.stacks = object.stacks ?? 0;
+    return message;
+  },
+};
+
+function createBaseAbilityTimers(): AbilityTimers {
+  return { timers: [] };
+}
+
+export const AbilityTimers: MessageFns<AbilityTimers> = {
+  encode(message: AbilityTimers, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.timers) {
+      AbilityTimer.encode(v!, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): AbilityTimers {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAbilityTimers();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.timers.push(AbilityTimer.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): AbilityTimers {
+    return {
+      timers: globalThis.Array.isArray(object?.timers) ? object.timers.map((e: any) => AbilityTimer.fromJSON(e)) : [],
+    };
+  },
+
+  toJSON(message: AbilityTimers): unknown {
+    const obj: any = {};
+    if (message.timers?.length) {
+      obj.timers = message.timers.map((e) => AbilityTimer.toJSON(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<AbilityTimers>, I>>(base?: I): AbilityTimers {
+    return AbilityTimers.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<AbilityTimers>, I>>(object: I): AbilityTimers {
+    const message = createBaseAbilityTimers();
+    message.timers = object.timers?.map((e) => AbilityTimer.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseEntityDelta(): EntityDelta {
+  return {
+    id: "",
+    position: undefined,
+    velocity: undefined,
+    rotation: undefined,
+    score: undefined,
+    flags: undefined,
+    abilities: undefined,
+    health: undefined,
+    isInvulnerable: undefined,
+    heat: undefined,
+    isOverheated: undefined,
+    fireInterval: undefined,
+  };
+}
+
+export const EntityDelta: MessageFns<EntityDelta> = {
+  encode(message: EntityDelta, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.position !== undefined) {
+      Vector.encode(message.position, writer.uint32(18).fork()).join();
+    }
+    if (message.velocity !== undefined) {
+      Vector.encode(message.velocity, writer.uint32(26).fork()).join();
+    }
+    if (message.rotation !== undefined) {
+      writer.uint32(33).double(message.rotation);
+    }
+    if (message.score !== undefined) {
+      writer.uint32(40).uint32(message.score);
+    }
+    if (message.flags !== undefined) {
+      writer.uint32(48).uint32(message.flags);
+    }
+    if (message.abilities !== undefined) {
+      AbilityTimers.encode(message.abilities, writer.uint32(58).fork()).join();
+    }
+    if (message.health !== undefined) {
+      writer.uint32(64).uint32(message.health);
+    }
+    if (message.isInvulnerable !== undefined) {
+      writer.uint32(72).bool(message.isInvulnerable);
+    }
+    if (message.heat !== undefined) {
+      writer.uint32(80).uint32(message.heat);
+    }
+    if (message.isOverheated !== undefined) {
+      writer.uint32(88).bool(message.isOverheated);
+    }
+    if (message.fireInterval !== undefined) {
+      writer.uint32(96).uint32(message.fireInterval);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): EntityDelta {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEntityDelta();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.position = Vector.decode(reader, reader.uint32());
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.velocity = Vector.decode(reader, reader.uint32());
+          continue;
+        }
+        case 4: {
+          if (tag !== 33) {
+            break;
+          }
+
+          message.rotation = reader.double();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.score = reader.uint32();
+          continue;
+        }
+        case 6: {
+          if (tag !== 48) {
+            break;
+          }
+
+          message.flags = reader.uint32();
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.abilities = AbilityTimers.decode(reader, reader.uint32());
+          continue;
+        }
+        case 8: {
+          if (tag !== 64) {
+            break;
+          }
+
+          message.health = reader.uint32();
+          continue;
+        }
+        case 9: {
+          if (tag !== 72) {
+            break;
+          }
+
+          message.isInvulnerable = reader.bool();
+          continue;
+        }
+        case 10: {
+          if (tag !== 80) {
+            break;
+          }
+
+          message.heat = reader.uint32();
+          continue;
+        }
+        case 11: {
+          if (tag !== 88) {
+            break;
+          }
+
+          message.isOverheated = reader.bool();
+          continue;
+        }
+        case 12: {
+          if (tag !== 96) {
+            break;
+          }
+
+          message.fireInterval = reader.uint32();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): EntityDelta {
+    return {
+      id: isSet(object.id) ? globalThis.String(object.id) : "",
+      position: isSet(object.position) ? Vector.fromJSON(object.position) : undefined,
+      velocity: isSet(object.velocity) ? Vector.fromJSON(object.velocity) : undefined,
+      rotation: isSet(object.rotation) ? globalThis.Number(object.rotation) : undefined,
+      score: isSet(object.score) ? globalThis.Number(object.score) : undefined,
+      flags: isSet(object.flags) ? globalThis.Number(object.flags) : undefined,
+      abilities: isSet(object.abilities) ? AbilityTimers.fromJSON(object.abilities) : undefined,
+      health: isSet(object.health) ? globalThis.Number(object.health) : undefined,
+      isInvulnerable: isSet(object.isInvulnerable) ? globalThis.Boolean(object.isInvulnerable) : undefined,
+      heat: isSet(object.heat) ? globalThis.Number(object.heat) : undefined,
+      isOverheated: isSet(object.isOverheated) ? globalThis.Boolean(object.isOverheated) : undefined,
+      fireInterval: isSet(object.fireInterval) ? globalThis.Number(object.fireInterval) : undefined,
+    };
+  },
+
+  toJSON(message: EntityDelta): unknown {
+    const obj: any = {};
+    if (message.id !== "") {
+      obj.id = message.id;
+    }
+    if (message.position !== undefined) {
+      obj.position = Vector.toJSON(message.position);
+    }
+    if (message.velocity !== undefined) {
+      obj.velocity = Vector.toJSON(message.velocity);
+    }
+    if (message.rotation !== undefined) {
+      obj.rotation = message.rotation;
+    }
+    if (message.score !== undefined) {
+      obj.score = Math.round(message.score);
+    }
+    if (message.flags !== undefined) {
+      obj.flags = Math.round(message.flags);
+    }
+    if (message.abilities !== undefined) {
+      obj.abilities = AbilityTimers.toJSON(message.abilities);
+    }
+    if (message.health !== undefined) {
+      obj.health = Math.round(message.health);
+    }
+    if (message.isInvulnerable !== undefined) {
+      obj.isInvulnerable = message.isInvulnerable;
+    }
+    if (message.heat !== undefined) {
+      obj.heat = Math.round(message.heat);
+    }
+    if (message.isOverheated !== undefined) {
+      obj.isOverheated = message.isOverheated;
+    }
+    if (message.fireInterval !== undefined) {
+      obj.fireInterval = Math.round(message.fireInterval);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<EntityDelta>, I>>(base?: I): EntityDelta {
+    return EntityDelta.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<EntityDelta>, I>>(object: I): EntityDelta {
+    const message = createBaseEntityDelta();
+    message.id = object.id ?? "";
+    message.position = (object.position !== undefined && object.position !== null)
+      ? Vector.fromPartial(object.position)
+      : undefined;
+    message.velocity = (object.velocity !== undefined && object.velocity !== null)
+      ? Vector.fromPartial(object.velocity)
+      : undefined;
+    message.rotation = object.rotation ?? undefined;
+    message.score = object.score ?? undefined;
+    message.flags = object.flags ?? undefined;
+    message.abilities = (object.abilities !== undefined && object.abilities !== null)
+      ? AbilityTimers.fromPartial(object.abilities)
+      : undefined;
+    message.health = object.health ?? undefined;
+    message.isInvulnerable = object.isInvulnerable ?? undefined;
+    message.heat = object.heat ?? undefined;
+    message.isOverheated = object.isOverheated ?? undefined;
+    message.fireInterval = object.fireInterval ?? undefined;
+    return message;
+  },
+};
+
 type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
 
 export type DeepPartial<T> = T extends Builtin ? T
@@ -6,10 +6,88 @@
 
 /* eslint-disable */
 import { BinaryReader, BinaryWriter } from "@bufbuild/protobuf/wire";
-import { EntityData } from "./entities";
+import { DamageCause, EntityData, EntityDelta, damageCauseFromJSON, damageCauseToJSON } from "./entities";
 
 export const protobufPackage = "dogfight";
 
+export enum GameMode {
+  GAME_MODE_FREE_FOR_ALL = 0,
+  GAME_MODE_TEAM_DEATHMATCH = 1,
+  GAME_MODE_FIRST_TO_N = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function gameModeFromJSON(object: any): GameMode {
+  switch (object) {
+    case 0:
+    case "GAME_MODE_FREE_FOR_ALL":
+      return GameMode.GAME_MODE_FREE_FOR_ALL;
+    case 1:
+    case "GAME_MODE_TEAM_DEATHMATCH":
+      return GameMode.GAME_MODE_TEAM_DEATHMATCH;
+    case 2:
+    case "GAME_MODE_FIRST_TO_N":
+      return GameMode.GAME_MODE_FIRST_TO_N;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return GameMode.UNRECOGNIZED;
+  }
+}
+
+export function gameModeToJSON(object: GameMode): string {
+  switch (object) {
+    case GameMode.GAME_MODE_FREE_FOR_ALL:
+      return "GAME_MODE_FREE_FOR_ALL";
+    case GameMode.GAME_MODE_TEAM_DEATHMATCH:
+      return "GAME_MODE_TEAM_DEATHMATCH";
+    case GameMode.GAME_MODE_FIRST_TO_N:
+      return "GAME_MODE_FIRST_TO_N";
+    case GameMode.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
+export enum Boundary {
+  BOUNDARY_WRAP = 0,
+  BOUNDARY_BOUNCE = 1,
+  BOUNDARY_SHRINKING_ZONE = 2,
+  UNRECOGNIZED = -1,
+}
+
+export function boundaryFromJSON(object: any): Boundary {
+  switch (object) {
+    case 0:
+    case "BOUNDARY_WRAP":
+      return Boundary.BOUNDARY_WRAP;
+    case 1:
+    case "BOUNDARY_BOUNCE":
+      return Boundary.BOUNDARY_BOUNCE;
+    case 2:
+    case "BOUNDARY_SHRINKING_ZONE":
+      return Boundary.BOUNDARY_SHRINKING_ZONE;
+    case -1:
+    case "UNRECOGNIZED":
+    default:
+      return Boundary.UNRECOGNIZED;
+  }
+}
+
+export function boundaryToJSON(object: Boundary): string {
+  switch (object) {
+    case Boundary.BOUNDARY_WRAP:
+      return "BOUNDARY_WRAP";
+    case Boundary.BOUNDARY_BOUNCE:
+      return "BOUNDARY_BOUNCE";
+    case Boundary.BOUNDARY_SHRINKING_ZONE:
+      return "BOUNDARY_SHRINKING_ZONE";
+    case Boundary.UNRECOGNIZED:
+    default:
+      return "UNRECOGNIZED";
+  }
+}
+
 export enum EventType {
   EVENT_TYPE_UNKNOWN = 0,
   EVENT_TYPE_JOIN = 1,
@@ -18,6 +96,10 @@ export enum EventType {
   EVENT_TYPE_INPUT = 4,
   EVENT_TYPE_SNAPSHOT = 5,
   EVENT_TYPE_DELTA = 6,
+  EVENT_TYPE_SPECTATE = 7,
+  EVENT_TYPE_ROUND = 8,
+  EVENT_TYPE_DEATH = 9,
+  EVENT_TYPE_RESUME = 10,
   UNRECOGNIZED = -1,
 }
 
@@ -44,6 +126,18 @@ export function eventTypeFromJSON(object: any): EventType {
     case 6:
     case "EVENT_TYPE_DELTA":
       return EventType.EVENT_TYPE_DELTA;
+    case 7:
+    case "EVENT_TYPE_SPECTATE":
+      return EventType.EVENT_TYPE_SPECTATE;
+    case 8:
+    case "EVENT_TYPE_ROUND":
+      return EventType.EVENT_TYPE_ROUND;
+    case 9:
+    case "EVENT_TYPE_DEATH":
+      return EventType.EVENT_TYPE_DEATH;
+    case 10:
+    case "EVENT_TYPE_RESUME":
+      return EventType.EVENT_TYPE_RESUME;
     case -1:
     case "UNRECOGNIZED":
     default:
@@ -67,6 +161,14 @@ export function eventTypeToJSON(object: EventType): string {
       return "EVENT_TYPE_SNAPSHOT";
     case EventType.EVENT_TYPE_DELTA:
       return "EVENT_TYPE_DELTA";
+    case EventType.EVENT_TYPE_SPECTATE:
+      return "EVENT_TYPE_SPECTATE";
+    case EventType.EVENT_TYPE_ROUND:
+      return "EVENT_TYPE_ROUND";
+    case EventType.EVENT_TYPE_DEATH:
+      return "EVENT_TYPE_DEATH";
+    case EventType.EVENT_TYPE_RESUME:
+      return "EVENT_TYPE_RESUME";
     case EventType.UNRECOGNIZED:
     default:
       return "UNRECOGNIZED";
@@ -81,19 +183,26 @@ export interface Event {
   inputEventData?: Event_InputEventData | undefined;
   snapshotEventData?: Event_SnapshotEventData | undefined;
   deltaEventData?: Event_DeltaEventData | undefined;
+  spectateEventData?: Event_SpectateEventData | undefined;
+  roundEventData?: Event_RoundEventData | undefined;
+  deathEventData?: Event_DeathEventData | undefined;
+  resumeEventData?: Event_ResumeEventData | undefined;
 }
 
 export interface Event_JoinEventData {
   id: string;
   username: string;
+  isBot: boolean;
 }
 
 export interface Event_QuitEventData {
   id: string;
+  isBot: boolean;
 }
 
 export interface Event_RespawnEventData {
   id: string;
+  isBot: boolean;
 }
 
 export interface Event_InputEventData {
@@ -101,17 +210,71 @@ export interface Event_InputEventData {
   mouseX: number;
   mouseY: number;
   mousePressed: boolean;
+  sequence: number;
+  tick: number;
 }
 
 export interface Event_SnapshotEventData {
   timestamp: number;
   entities: EntityData[];
+  seed: number;
+  worldSize: number;
+  boundary: Boundary;
+  zoneSize: number;
+  config: MatchConfig | undefined;
 }
 
 export interface Event_DeltaEventData {
   timestamp: number;
   updated: EntityData[];
   removed: string[];
+  changed: EntityDelta[];
+  hidden: string[];
+  tick: number;
+  inputSequence: number;
+  zoneSize: number;
+}
+
+export interface Event_SpectateEventData {
+  targetId: string;
+}
+
+export interface Event_RoundEventData {
+  mode: GameMode;
+  round: number;
+  isOver: boolean;
+  duration: number;
+  scores: Event_RoundEventData_Score[];
+  winnerId: string;
+  winnerTeam: number;
+  startTick: number;
+}
+
+export interface Event_RoundEventData_Score {
+  id: string;
+  username: string;
+  team: number;
+  kills: number;
+}
+
+export interface Event_DeathEventData {
+  victimId: string;
+  killerId: string;
+  cause: DamageCause;
+}
+
+export interface Event_ResumeEventData {
+  id: string;
+}
+
+export interface MatchConfig {
+  mode: GameMode;
+  scoreLimit: number;
+  botCount: number;
+  rewindWindow: number;
+  roundDuration: number;
+  worldSize: number;
+  boundary: Boundary;
 }
 
 function createBaseEvent(): Event {
@@ -123,6 +286,10 @@ function createBaseEvent(): Event {
     inputEventData: undefined,
     snapshotEventData: undefined,
     deltaEventData: undefined,
+    spectateEventData: undefined,
+    roundEventData: undefined,
+    deathEventData: undefined,
+    resumeEventData: undefined,
   };
 }
 
@@ -149,6 +316,18 @@ export const Event: MessageFns<Event> = {
     if (message.deltaEventData !== undefined) {
       Event_DeltaEventData.encode(message.deltaEventData, writer.uint32(58).fork()).join();
     }
+    if (message.spectateEventData !== undefined) {
+      Event_SpectateEventData.encode(message.spectateEventData, writer.uint32(66).fork()).join();
+    }
+    if (message.roundEventData !== undefined) {
+      Event_RoundEventData.encode(message.roundEventData, writer.uint32(74).fork()).join();
+    }
+    if (message.deathEventData !== undefined) {
+      Event_DeathEventData.encode(message.deathEventData, writer.uint32(82).fork()).join();
+    }
+    if (message.resumeEventData !== undefined) {
+      Event_ResumeEventData.encode(message.resumeEventData, writer.uint32(90).fork()).join();
+    }
     return writer;
   },
 
@@ -215,6 +394,38 @@ export const Event: MessageFns<Event> = {
           message.deltaEventData = Event_DeltaEventData.decode(reader, reader.uint32());
           continue;
         }
+        case 8: {
+          if (tag !== 66) {
+            break;
+          }
+
+          message.spectateEventData = Event_SpectateEventData.decode(reader, reader.uint32());
+          continue;
+        }
+        case 9: {
+          if (tag !== 74) {
+            break;
+          }
+
+          message.roundEventData = Event_RoundEventData.decode(reader, reader.uint32());
+          continue;
+        }
+        case 10: {
+          if (tag !== 82) {
+            break;
+          }
+
+          message.deathEventData = Event_DeathEventData.decode(reader, reader.uint32());
+          continue;
+        }
+        case 11: {
+          if (tag !== 90) {
+            break;
+          }
+
+          message.resumeEventData = Event_ResumeEventData.decode(reader, reader.uint32());
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -237,6 +448,14 @@ export const Event: MessageFns<Event> = {
         ? Event_SnapshotEventData.fromJSON(object.snapshotEventData)
         : undefined,
       deltaEventData: isSet(object.deltaEventData) ? Event_DeltaEventData.fromJSON(object.deltaEventData) : undefined,
+      spectateEventData: isSet(object.spectateEventData)
+        ? Event_SpectateEventData.fromJSON(object.spectateEventData)
+        : undefined,
+      roundEventData: isSet(object.roundEventData) ? Event_RoundEventData.fromJSON(object.roundEventData) : undefined,
+      deathEventData: isSet(object.deathEventData) ? Event_DeathEventData.fromJSON(object.deathEventData) : undefined,
+      resumeEventData: isSet(object.resumeEventData)
+        ? Event_ResumeEventData.fromJSON(object.resumeEventData)
+        : undefined,
     };
   },
 
@@ -263,6 +482,18 @@ export const Event: MessageFns<Event> = {
     if (message.deltaEventData !== undefined) {
       obj.deltaEventData = Event_DeltaEventData.toJSON(message.deltaEventData);
     }
+    if (message.spectateEventData !== undefined) {
+      obj.spectateEventData = Event_SpectateEventData.toJSON(message.spectateEventData);
+    }
+    if (message.roundEventData !== undefined) {
+      obj.roundEventData = Event_RoundEventData.toJSON(message.roundEventData);
+    }
+    if (message.deathEventData !== undefined) {
+      obj.deathEventData = Event_DeathEventData.toJSON(message.deathEventData);
+    }
+    if (message.resumeEventData !== undefined) {
+      obj.resumeEventData = Event_ResumeEventData.toJSON(message.resumeEventData);
+    }
     return obj;
   },
 
@@ -290,12 +521,24 @@ export const Event: MessageFns<Event> = {
     message.deltaEventData = (object.deltaEventData !== undefined && object.deltaEventData !== null)
       ? Event_DeltaEventData.fromPartial(object.deltaEventData)
       : undefined;
+    message.spectateEventData = (object.spectateEventData !== undefined && object.spectateEventData !== null)
+      ? Event_SpectateEventData.fromPartial(object.spectateEventData)
+      : undefined;
+    message.roundEventData = (object.roundEventData !== undefined && object.roundEventData !== null)
+      ? Event_RoundEventData.fromPartial(object.roundEventData)
+      : undefined;
+    message.deathEventData = (object.deathEventData !== undefined && object.deathEventData !== null)
+      ? Event_DeathEventData.fromPartial(object.deathEventData)
+      : undefined;
+    message.resumeEventData = (object.resumeEventData !== undefined && object.resumeEventData !== null)
+      ? Event_ResumeEventData.fromPartial(object.resumeEventData)
+      : undefined;
     return message;
   },
 };
 
 function createBaseEvent_JoinEventData(): Event_JoinEventData {
-  return { id: "", username: "" };
+  return { id: "", username: "", isBot: false };
 }
 
 export const Event_JoinEventData: MessageFns<Event_JoinEventData> = {
@@ -306,6 +549,9 @@ export const Event_JoinEventData: MessageFns<Event_JoinEventData> = {
     if (message.username !== "") {
       writer.uint32(18).string(message.username);
     }
+    if (message.isBot !== false) {
+      writer.uint32(24).bool(message.isBot);
+    }
     return writer;
   },
 
@@ -332,6 +578,14 @@ export const Event_JoinEventData: MessageFns<Event_JoinEventData> = {
           message.username = reader.string();
           continue;
         }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.isBot = reader.bool();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -345,6 +599,7 @@ export const Event_JoinEventData: MessageFns<Event_JoinEventData> = {
     return {
       id: isSet(object.id) ? globalThis.String(object.id) : "",
       username: isSet(object.username) ? globalThis.String(object.username) : "",
+      isBot: isSet(object.isBot) ? globalThis.Boolean(object.isBot) : false,
     };
   },
 
@@ -356,6 +611,9 @@ export const Event_JoinEventData: MessageFns<Event_JoinEventData> = {
     if (message.username !== "") {
       obj.username = message.username;
     }
+    if (message.isBot !== false) {
+      obj.isBot = message.isBot;
+    }
     return obj;
   },
 
@@ -366,12 +624,13 @@ export const Event_JoinEventData: MessageFns<Event_JoinEventData> = {
     const message = createBaseEvent_JoinEventData();
     message.id = object.id ?? "";
     message.username = object.username ?? "";
+    message.isBot = object.isBot ?? false;
     return message;
   },
 };
 
 function createBaseEvent_QuitEventData(): Event_QuitEventData {
-  return { id: "" };
+  return { id: "", isBot: false };
 }
 
 export const Event_QuitEventData: MessageFns<Event_QuitEventData> = {
@@ -379,6 +638,9 @@ export const Event_QuitEventData: MessageFns<Event_QuitEventData> = {
     if (message.id !== "") {
       writer.uint32(10).string(message.id);
     }
+    if (message.isBot !== false) {
+      writer.uint32(16).bool(message.isBot);
+    }
     return writer;
   },
 
@@ -397,6 +659,14 @@ export const Event_QuitEventData: MessageFns<Event_QuitEventData> = {
           message.id = reader.string();
           continue;
         }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.isBot = reader.bool();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -407,7 +677,10 @@ export const Event_QuitEventData: MessageFns<Event_QuitEventData> = {
   },
 
   fromJSON(object: any): Event_QuitEventData {
-    return { id: isSet(object.id) ? globalThis.String(object.id) : "" };
+    return {
+      id: isSet(object.id) ? globalThis.String(object.id) : "",
+      isBot: isSet(object.isBot) ? globalThis.Boolean(object.isBot) : false,
+    };
   },
 
   toJSON(message: Event_QuitEventData): unknown {
@@ -415,6 +688,9 @@ export const Event_QuitEventData: MessageFns<Event_QuitEventData> = {
     if (message.id !== "") {
       obj.id = message.id;
     }
+    if (message.isBot !== false) {
+      obj.isBot = message.isBot;
+    }
     return obj;
   },
 
@@ -424,12 +700,13 @@ export const Event_QuitEventData: MessageFns<Event_QuitEventData> = {
   fromPartial<I extends Exact<DeepPartial<Event_QuitEventData>, I>>(object: I): Event_QuitEventData {
     const message = createBaseEvent_QuitEventData();
     message.id = object.id ?? "";
+    message.isBot = object.isBot ?? false;
     return message;
   },
 };
 
 function createBaseEvent_RespawnEventData(): Event_RespawnEventData {
-  return { id: "" };
+  return { id: "", isBot: false };
 }
 
 export const Event_RespawnEventData: MessageFns<Event_RespawnEventData> = {
@@ -437,6 +714,9 @@ export const Event_RespawnEventData: MessageFns<Event_RespawnEventData> = {
     if (message.id !== "") {
       writer.uint32(10).string(message.id);
     }
+    if (message.isBot !== false) {
+      writer.uint32(16).bool(message.isBot);
+    }
     return writer;
   },
 
@@ -455,6 +735,14 @@ export const Event_RespawnEventData: MessageFns<Event_RespawnEventData> = {
           message.id = reader.string();
           continue;
         }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.isBot = reader.bool();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -465,7 +753,10 @@ export const Event_RespawnEventData: MessageFns<Event_RespawnEventData> = {
   },
 
   fromJSON(object: any): Event_RespawnEventData {
-    return { id: isSet(object.id) ? globalThis.String(object.id) : "" };
+    return {
+      id: isSet(object.id) ? globalThis.String(object.id) : "",
+      isBot: isSet(object.isBot) ? globalThis.Boolean(object.isBot) : false,
+    };
   },
 
   toJSON(message: Event_RespawnEventData): unknown {
@@ -473,6 +764,9 @@ export const Event_RespawnEventData: MessageFns<Event_RespawnEventData> = {
     if (message.id !== "") {
       obj.id = message.id;
     }
+    if (message.isBot !== false) {
+      obj.isBot = message.isBot;
+    }
     return obj;
   },
 
@@ -482,12 +776,13 @@ export const Event_RespawnEventData: MessageFns<Event_RespawnEventData> = {
   fromPartial<I extends Exact<DeepPartial<Event_RespawnEventData>, I>>(object: I): Event_RespawnEventData {
     const message = createBaseEvent_RespawnEventData();
     message.id = object.id ?? "";
+    message.isBot = object.isBot ?? false;
     return message;
   },
 };
 
 function createBaseEvent_InputEventData(): Event_InputEventData {
-  return { id: "", mouseX: 0, mouseY: 0, mousePressed: false };
+  return { id: "", mouseX: 0, mouseY: 0, mousePressed: false, sequence: 0, tick: 0 };
 }
 
 export const Event_InputEventData: MessageFns<Event_InputEventData> = {
@@ -504,6 +799,12 @@ export const Event_InputEventData: MessageFns<Event_InputEventData> = {
     if (message.mousePressed !== false) {
       writer.uint32(32).bool(message.mousePressed);
     }
+    if (message.sequence !== 0) {
+      writer.uint32(40).uint32(message.sequence);
+    }
+    if (message.tick !== 0) {
+      writer.uint32(48).uint32(message.tick);
+    }
     return writer;
   },
 
@@ -546,6 +847,22 @@ export const Event_InputEventData: MessageFns<Event_InputEventData> = {
           message.mousePressed = reader.bool();
           continue;
         }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.sequence = reader.uint32();
+          continue;
+        }
+        case 6: {
+          if (tag !== 48) {
+            break;
+          }
+
+          message.tick = reader.uint32();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -561,6 +878,8 @@ export const Event_InputEventData: MessageFns<Event_InputEventData> = {
       mouseX: isSet(object.mouseX) ? globalThis.Number(object.mouseX) : 0,
       mouseY: isSet(object.mouseY) ? globalThis.Number(object.mouseY) : 0,
       mousePressed: isSet(object.mousePressed) ? globalThis.Boolean(object.mousePressed) : false,
+      sequence: isSet(object.sequence) ? globalThis.Number(object.sequence) : 0,
+      tick: isSet(object.tick) ? globalThis.Number(object.tick) : 0,
     };
   },
 
@@ -578,6 +897,12 @@ export const Event_InputEventData: MessageFns<Event_InputEventData> = {
     if (message.mousePressed !== false) {
       obj.mousePressed = message.mousePressed;
     }
+    if (message.sequence !== 0) {
+      obj.sequence = Math.round(message.sequence);
+    }
+    if (message.tick !== 0) {
+      obj.tick = Math.round(message.tick);
+    }
     return obj;
   },
 
@@ -590,12 +915,14 @@ export const Event_InputEventData: MessageFns<Event_InputEventData> = {
     message.mouseX = object.mouseX ?? 0;
     message.mouseY = object.mouseY ?? 0;
     message.mousePressed = object.mousePressed ?? false;
+    message.sequence = object.sequence ?? 0;
+    message.tick = object.tick ?? 0;
     return message;
   },
 };
 
 function createBaseEvent_SnapshotEventData(): Event_SnapshotEventData {
-  return { timestamp: 0, entities: [] };
+  return { timestamp: 0, entities: [], seed: 0, worldSize: 0, boundary: 0, zoneSize: 0, config: undefined };
 }
 
 export const Event_SnapshotEventData: MessageFns<Event_SnapshotEventData> = {
@@ -606,6 +933,21 @@ export const Event_SnapshotEventData: MessageFns<Event_SnapshotEventData> = {
     for (const v of message.entities) {
       EntityData.encode(v!, writer.uint32(18).fork()).join();
     }
+    if (message.seed !== 0) {
+      writer.uint32(24).uint32(message.seed);
+    }
+    if (message.worldSize !== 0) {
+      writer.uint32(33).double(message.worldSize);
+    }
+    if (message.boundary !== 0) {
+      writer.uint32(40).int32(message.boundary);
+    }
+    if (message.zoneSize !== 0) {
+      writer.uint32(49).double(message.zoneSize);
+    }
+    if (message.config !== undefined) {
+      MatchConfig.encode(message.config, writer.uint32(58).fork()).join();
+    }
     return writer;
   },
 
@@ -632,6 +974,46 @@ export const Event_SnapshotEventData: MessageFns<Event_SnapshotEventData> = {
           message.entities.push(EntityData.decode(reader, reader.uint32()));
           continue;
         }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.seed = reader.uint32();
+          continue;
+        }
+        case 4: {
+          if (tag !== 33) {
+            break;
+          }
+
+          message.worldSize = reader.double();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.boundary = reader.int32() as any;
+          continue;
+        }
+        case 6: {
+          if (tag !== 49) {
+            break;
+          }
+
+          message.zoneSize = reader.double();
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.config = MatchConfig.decode(reader, reader.uint32());
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -647,6 +1029,11 @@ export const Event_SnapshotEventData: MessageFns<Event_SnapshotEventData> = {
       entities: globalThis.Array.isArray(object?.entities)
         ? object.entities.map((e: any) => EntityData.fromJSON(e))
         : [],
+      seed: isSet(object.seed) ? globalThis.Number(object.seed) : 0,
+      worldSize: isSet(object.worldSize) ? globalThis.Number(object.worldSize) : 0,
+      boundary: isSet(object.boundary) ? boundaryFromJSON(object.boundary) : 0,
+      zoneSize: isSet(object.zoneSize) ? globalThis.Number(object.zoneSize) : 0,
+      config: isSet(object.config) ? MatchConfig.fromJSON(object.config) : undefined,
     };
   },
 
@@ -658,6 +1045,21 @@ export const Event_SnapshotEventData: MessageFns<Event_SnapshotEventData> = {
     if (message.entities?.length) {
       obj.entities = message.entities.map((e) => EntityData.toJSON(e));
     }
+    if (message.seed !== 0) {
+      obj.seed = Math.round(message.seed);
+    }
+    if (message.worldSize !== 0) {
+      obj.worldSize = message.worldSize;
+    }
+    if (message.boundary !== 0) {
+      obj.boundary = boundaryToJSON(message.boundary);
+    }
+    if (message.zoneSize !== 0) {
+      obj.zoneSize = message.zoneSize;
+    }
+    if (message.config !== undefined) {
+      obj.config = MatchConfig.toJSON(message.config);
+    }
     return obj;
   },
 
@@ -668,12 +1070,19 @@ export const Event_SnapshotEventData: MessageFns<Event_SnapshotEventData> = {
     const message = createBaseEvent_SnapshotEventData();
     message.timestamp = object.timestamp ?? 0;
     message.entities = object.entities?.map((e) => EntityData.fromPartial(e)) || [];
+    message.seed = object.seed ?? 0;
+    message.worldSize = object.worldSize ?? 0;
+    message.boundary = object.boundary ?? 0;
+    message.zoneSize = object.zoneSize ?? 0;
+    message.config = (object.config !== undefined && object.config !== null)
+      ? MatchConfig.fromPartial(object.config)
+      : undefined;
     return message;
   },
 };
 
 function createBaseEvent_DeltaEventData(): Event_DeltaEventData {
-  return { timestamp: 0, updated: [], removed: [] };
+  return { timestamp: 0, updated: [], removed: [], changed: [], hidden: [], tick: 0, inputSequence: 0, zoneSize: 0 };
 }
 
 export const Event_DeltaEventData: MessageFns<Event_DeltaEventData> = {
@@ -687,6 +1096,21 @@ export const Event_DeltaEventData: MessageFns<Event_DeltaEventData> = {
     for (const v of message.removed) {
       writer.uint32(26).string(v!);
     }
+    for (const v of message.changed) {
+      EntityDelta.encode(v!, writer.uint32(34).fork()).join();
+    }
+    for (const v of message.hidden) {
+      writer.uint32(42).string(v!);
+    }
+    if (message.tick !== 0) {
+      writer.uint32(48).uint32(message.tick);
+    }
+    if (message.inputSequence !== 0) {
+      writer.uint32(56).uint32(message.inputSequence);
+    }
+    if (message.zoneSize !== 0) {
+      writer.uint32(65).double(message.zoneSize);
+    }
     return writer;
   },
 
@@ -721,6 +1145,46 @@ export const Event_DeltaEventData: MessageFns<Event_DeltaEventData> = {
           message.removed.push(reader.string());
           continue;
         }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.changed.push(EntityDelta.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.hidden.push(reader.string());
+          continue;
+        }
+        case 6: {
+          if (tag !== 48) {
+            break;
+          }
+
+          message.tick = reader.uint32();
+          continue;
+        }
+        case 7: {
+          if (tag !== 56) {
+            break;
+          }
+
+          message.inputSequence = reader.uint32();
+          continue;
+        }
+        case 8: {
+          if (tag !== 65) {
+            break;
+          }
+
+          message.zoneSize = reader.double();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -735,6 +1199,11 @@ export const Event_DeltaEventData: MessageFns<Event_DeltaEventData> = {
       timestamp: isSet(object.timestamp) ? globalThis.Number(object.timestamp) : 0,
       updated: globalThis.Array.isArray(object?.updated) ? object.updated.map((e: any) => EntityData.fromJSON(e)) : [],
       removed: globalThis.Array.isArray(object?.removed) ? object.removed.map((e: any) => globalThis.String(e)) : [],
+      changed: globalThis.Array.isArray(object?.changed) ? object.changed.map((e: any) => EntityDelta.fromJSON(e)) : [],
+      hidden: globalThis.Array.isArray(object?.hidden) ? object.hidden.map((e: any) => globalThis.String(e)) : [],
+      tick: isSet(object.tick) ? globalThis.Number(object.tick) : 0,
+      inputSequence: isSet(object.inputSequence) ? globalThis.Number(object.inputSequence) : 0,
+      zoneSize: isSet(object.zoneSize) ? globalThis.Number(object.zoneSize) : 0,
     };
   },
 
@@ -749,6 +1218,21 @@ export const Event_DeltaEventData: MessageFns<Event_DeltaEventData> = {
     if (message.removed?.length) {
       obj.removed = message.removed;
     }
+    if (message.changed?.length) {
+      obj.changed = message.changed.map((e) => EntityDelta.toJSON(e));
+    }
+    if (message.hidden?.length) {
+      obj.hidden = message.hidden;
+    }
+    if (message.tick !== 0) {
+      obj.tick = Math.round(message.tick);
+    }
+    if (message.inputSequence !== 0) {
+      obj.inputSequence = Math.round(message.inputSequence);
+    }
+    if (message.zoneSize !== 0) {
+      obj.zoneSize = message.zoneSize;
+    }
     return obj;
   },
 
@@ -760,6 +1244,657 @@ export const Event_DeltaEventData: MessageFns<Event_DeltaEventData> = {
     message.timestamp = object.timestamp ?? 0;
     message.updated = object.updated?.map((e) => EntityData.fromPartial(e)) || [];
     message.removed = object.removed?.map((e) => e) || [];
+    message.changed = object.changed?.map((e) => EntityDelta.fromPartial(e)) || [];
+    message.hidden = object.hidden?.map((e) => e) || [];
+    message.tick = object.tick ?? 0;
+    message.inputSequence = object.inputSequence ?? 0;
+    message.zoneSize = object.zoneSize ?? 0;
+    return message;
+  },
+};
+
+function createBaseEvent_SpectateEventData(): Event_SpectateEventData {
+  return { targetId: "" };
+}
+
+export const Event_SpectateEventData: MessageFns<Event_SpectateEventData> = {
+  encode(message: Event_SpectateEventData, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.targetId !== "") {
+      writer.uint32(10).string(message.targetId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): Event_SpectateEventData {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEvent_SpectateEventData();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.targetId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Event_SpectateEventData {
+    return { targetId: isSet(object.targetId) ? globalThis.String(object.targetId) : "" };
+  },
+
+  toJSON(message: Event_SpectateEventData): unknown {
+    const obj: any = {};
+    if (message.targetId !== "") {
+      obj.targetId = message.targetId;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<Event_SpectateEventData>, I>>(base?: I): Event_SpectateEventData {
+    return Event_SpectateEventData.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<Event_SpectateEventData>, I>>(object: I): Event_SpectateEventData {
+    const message = createBaseEvent_SpectateEventData();
+    message.targetId = object.targetId ?? "";
+    return message;
+  },
+};
+
+function createBaseEvent_RoundEventData(): Event_RoundEventData {
+  return { mode: 0, round: 0, isOver: false, duration: 0, scores: [], winnerId: "", winnerTeam: 0, startTick: 0 };
+}
+
+export const Event_RoundEventData: MessageFns<Event_RoundEventData> = {
+  encode(message: Event_RoundEventData, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.mode !== 0) {
+      writer.uint32(8).int32(message.mode);
+    }
+    if (message.round !== 0) {
+      writer.uint32(16).uint32(message.round);
+    }
+    if (message.isOver !== false) {
+      writer.uint32(24).bool(message.isOver);
+    }
+    if (message.duration !== 0) {
+      writer.uint32(32).uint32(message.duration);
+    }
+    for (const v of message.scores) {
+      Event_RoundEventData_Score.encode(v!, writer.uint32(42).fork()).join();
+    }
+    if (message.winnerId !== "") {
+      writer.uint32(50).string(message.winnerId);
+    }
+    if (message.winnerTeam !== 0) {
+      writer.uint32(56).uint32(message.winnerTeam);
+    }
+    if (message.startTick !== 0) {
+      writer.uint32(64).uint32(message.startTick);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): Event_RoundEventData {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEvent_RoundEventData();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 8) {
+            break;
+          }
+
+          message.mode = reader.int32() as any;
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.round = reader.uint32();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.isOver = reader.bool();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.duration = reader.uint32();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.scores.push(Event_RoundEventData_Score.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.winnerId = reader.string();
+          continue;
+        }
+        case 7: {
+          if (tag !== 56) {
+            break;
+          }
+
+          message.winnerTeam = reader.uint32();
+          continue;
+        }
+        case 8: {
+          if (tag !== 64) {
+            break;
+          }
+
+          message.startTick = reader.uint32();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Event_RoundEventData {
+    return {
+      mode: isSet(object.mode) ? gameModeFromJSON(object.mode) : 0,
+      round: isSet(object.round) ? globalThis.Number(object.round) : 0,
+      isOver: isSet(object.isOver) ? globalThis.Boolean(object.isOver) : false,
+      duration: isSet(object.duration) ? globalThis.Number(object.duration) : 0,
+      scores: globalThis.Array.isArray(object?.scores)
+        ? object.scores.map((e: any) => Event_RoundEventData_Score.fromJSON(e))
+        : [],
+      winnerId: isSet(object.winnerId) ? globalThis.String(object.winnerId) : "",
+      winnerTeam: isSet(object.winnerTeam) ? globalThis.Number(object.winnerTeam) : 0,
+      startTick: isSet(object.startTick) ? globalThis.Number(object.startTick) : 0,
+    };
+  },
+
+  toJSON(message: Event_RoundEventData): unknown {
+    const obj: any = {};
+    if (message.mode !== 0) {
+      obj.mode = gameModeToJSON(message.mode);
+    }
+    if (message.round !== 0) {
+      obj.round = Math.round(message.round);
+    }
+    if (message.isOver !== false) {
+      obj.isOver = message.isOver;
+    }
+    if (message.duration !== 0) {
+      obj.duration = Math.round(message.duration);
+    }
+    if (message.scores?.length) {
+      obj.scores = message.scores.map((e) => Event_RoundEventData_Score.toJSON(e));
+    }
+    if (message.winnerId !== "") {
+      obj.winnerId = message.winnerId;
+    }
+    if (message.winnerTeam !== 0) {
+      obj.winnerTeam = Math.round(message.winnerTeam);
+    }
+    if (message.startTick !== 0) {
+      obj.startTick = Math.round(message.startTick);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<Event_RoundEventData>, I>>(base?: I): Event_RoundEventData {
+    return Event_RoundEventData.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<Event_RoundEventData>, I>>(object: I): Event_RoundEventData {
+    const message = createBaseEvent_RoundEventData();
+    message.mode = object.mode ?? 0;
+    message.round = object.round ?? 0;
+    message.isOver = object.isOver ?? false;
+    message.duration = object.duration ?? 0;
+    message.scores = object.scores?.map((e) => Event_RoundEventData_Score.fromPartial(e)) || [];
+    message.winnerId = object.winnerId ?? "";
+    message.winnerTeam = object.winnerTeam ?? 0;
+    message.startTick = object.startTick ?? 0;
+    return message;
+  },
+};
+
+function createBaseEvent_RoundEventData_Score(): Event_RoundEventData_Score {
+  return { id: "", username: "", team: 0, kills: 0 };
+}
+
+export const Event_RoundEventData_Score: MessageFns<Event_RoundEventData_Score> = {
+  encode(message: Event_RoundEventData_Score, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.username !== "") {
+      writer.uint32(18).string(message.username);
+    }
+    if (message.team !== 0) {
+      writer.uint32(24).uint32(message.team);
+    }
+    if (message.kills !== 0) {
+      writer.uint32(32).uint32(message.kills);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): Event_RoundEventData_Score {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEvent_RoundEventData_Score();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.username = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.team = reader.uint32();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.kills = reader.uint32();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Event_RoundEventData_Score {
+    return {
+      id: isSet(object.id) ? globalThis.String(object.id) : "",
+      username: isSet(object.username) ? globalThis.String(object.username) : "",
+      team: isSet(object.team) ? globalThis.Number(object.team) : 0,
+      kills: isSet(object.kills) ? globalThis.Number(object.kills) : 0,
+    };
+  },
+
+  toJSON(message: Event_RoundEventData_Score): unknown {
+    const obj: any = {};
+    if (message.id !== "") {
+      obj.id = message.id;
+    }
+    if (message.username !== "") {
+      obj.username = message.username;
+    }
+    if (message.team !== 0) {
+      obj.team = Math.round(message.team);
+    }
+    if (message.kills !== 0) {
+      obj.kills = Math.round(message.kills);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<Event_RoundEventData_Score>, I>>(base?: I): Event_RoundEventData_Score {
+    return Event_RoundEventData_Score.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<Event_RoundEventData_Score>, I>>(object: I): Event_RoundEventData_Score {
+    const message = createBaseEvent_RoundEventData_Score();
+    message.id = object.id ?? "";
+    message.username = object.username ?? "";
+    message.team = object.team ?? 0;
+    message.kills = object.kills ?? 0;
+    return message;
+  },
+};
+
+function createBaseEvent_DeathEventData(): Event_DeathEventData {
+  return { victimId: "", killerId: "", cause: 0 };
+}
+
+export const Event_DeathEventData: MessageFns<Event_DeathEventData> = {
+  encode(message: Event_DeathEventData, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.victimId !== "") {
+      writer.uint32(10).string(message.victimId);
+    }
+    if (message.killerId !== "") {
+      writer.uint32(18).string(message.killerId);
+    }
+    if (message.cause !== 0) {
+      writer.uint32(24).int32(message.cause);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): Event_DeathEventData {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEvent_DeathEventData();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.victimId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.killerId = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.cause = reader.int32() as any;
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Event_DeathEventData {
+    return {
+      victimId: isSet(object.victimId) ? globalThis.String(object.victimId) : "",
+      killerId: isSet(object.killerId) ? globalThis.String(object.killerId) : "",
+      cause: isSet(object.cause) ? damageCauseFromJSON(object.cause) : 0,
+    };
+  },
+
+  toJSON(message: Event_DeathEventData): unknown {
+    const obj: any = {};
+    if (message.victimId !== "") {
+      obj.victimId = message.victimId;
+    }
+    if (message.killerId !== "") {
+      obj.killerId = message.killerId;
+    }
+    if (message.cause !== 0) {
+      obj.cause = damageCauseToJSON(message.cause);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<Event_DeathEventData>, I>>(base?: I): Event_DeathEventData {
+    return Event_DeathEventData.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<Event_DeathEventData>, I>>(object: I): Event_DeathEventData {
+    const message = createBaseEvent_DeathEventData();
+    message.victimId = object.victimId ?? "";
+    message.killerId = object.killerId ?? "";
+    message.cause = object.cause ?? 0;
+    return message;
+  },
+};
+
+function createBaseEvent_ResumeEventData(): Event_ResumeEventData {
+  return { id: "" };
+}
+
+export const Event_ResumeEventData: MessageFns<Event_ResumeEventData> = {
+  encode(message: Event_ResumeEventData, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): Event_ResumeEventData {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseEvent_ResumeEventData();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Event_ResumeEventData {
+    return { id: isSet(object.id) ? globalThis.String(object.id) : "" };
+  },
+
+  toJSON(message: Event_ResumeEventData): unknown {
+    const obj: any = {};
+    if (message.id !== "") {
+      obj.id = message.id;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<Event_ResumeEventData>, I>>(base?: I): Event_ResumeEventData {
+    return Event_ResumeEventData.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<Event_ResumeEventData>, I>>(object: I): Event_ResumeEventData {
+    const message = createBaseEvent_ResumeEventData();
+    message.id = object.id ?? "";
+    return message;
+  },
+};
+
+function createBaseMatchConfig(): MatchConfig {
+  return { mode: 0, scoreLimit: 0, botCount: 0, rewindWindow: 0, roundDuration: 0, worldSize: 0, boundary: 0 };
+}
+
+export const MatchConfig: MessageFns<MatchConfig> = {
+  encode(message: MatchConfig, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.mode !== 0) {
+      writer.uint32(8).int32(message.mode);
+    }
+    if (message.scoreLimit !== 0) {
+      writer.uint32(16).uint32(message.scoreLimit);
+    }
+    if (message.botCount !== 0) {
+      writer.uint32(24).uint32(message.botCount);
+    }
+    if (message.rewindWindow !== 0) {
+      writer.uint32(32).uint32(message.rewindWindow);
+    }
+    if (message.roundDuration !== 0) {
+      writer.uint32(40).uint32(message.roundDuration);
+    }
+    if (message.worldSize !== 0) {
+      writer.uint32(49).double(message.worldSize);
+    }
+    if (message.boundary !== 0) {
+      writer.uint32(56).int32(message.boundary);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): MatchConfig {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseMatchConfig();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 8) {
+            break;
+          }
+
+          message.mode = reader.int32() as any;
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.scoreLimit = reader.uint32();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.botCount = reader.uint32();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.rewindWindow = reader.uint32();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.roundDuration = reader.uint32();
+          continue;
+        }
+        case 6: {
+          if (tag !== 49) {
+            break;
+          }
+
+          message.worldSize = reader.double();
+          continue;
+        }
+        case 7: {
+          if (tag !== 56) {
+            break;
+          }
+
+          message.boundary = reader.int32() as any;
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): MatchConfig {
+    return {
+      mode: isSet(object.mode) ? gameModeFromJSON(object.mode) : 0,
+      scoreLimit: isSet(object.scoreLimit) ? globalThis.Number(object.scoreLimit) : 0,
+      botCount: isSet(object.botCount) ? globalThis.Number(object.botCount) : 0,
+      rewindWindow: isSet(object.rewindWindow) ? globalThis.Number(object.rewindWindow) : 0,
+      roundDuration: isSet(object.roundDuration) ? globalThis.Number(object.roundDuration) : 0,
+      worldSize: isSet(object.worldSize) ? globalThis.Number(object.worldSize) : 0,
+      boundary: isSet(object.boundary) ? boundaryFromJSON(object.boundary) : 0,
+    };
+  },
+
+  toJSON(message: MatchConfig): unknown {
+    const obj: any = {};
+    if (message.mode !== 0) {
+      obj.mode = gameModeToJSON(message.mode);
+    }
+    if (message.scoreLimit !== 0) {
+      obj.scoreLimit = Math.round(message.scoreLimit);
+    }
+    if (message.botCount !== 0) {
+      obj.botCount = Math.round(message.botCount);
+    }
+    if (message.rewindWindow !== 0) {
+      obj.rewindWindow = Math.round(message.rewindWindow);
+    }
+    if (message.roundDuration !== 0) {
+      obj.roundDuration = Math.round(message.roundDuration);
+    }
+    if (message.worldSize !== 0) {
+      obj.worldSize = message.worldSize;
+    }
+    if (message.boundary !== 0) {
+      obj.boundary = boundaryToJSON(message.boundary);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<MatchConfig>, I>>(base?: I): MatchConfig {
+    return MatchConfig.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<MatchConfig>, I>>(object: I): MatchConfig {
+    const message = createBaseMatchConfig();
+    message.mode = object.mode ?? 0;
+    message.scoreLimit = object.scoreLimit ?? 0;
+    message.botCount = object.botCount ?? 0;
+    message.rewindWindow = object.rewindWindow ?? 0;
+    message.roundDuration = object.roundDuration ?? 0;
+    message.worldSize = object.worldSize ?? 0;
+    message.boundary = object.boundary ?? 0;
     return message;
   },
 };
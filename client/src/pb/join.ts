@@ -6,22 +6,44 @@
 
 /* eslint-disable */
 import { BinaryReader, BinaryWriter } from "@bufbuild/protobuf/wire";
+import { Boundary, GameMode, boundaryFromJSON, boundaryToJSON, gameModeFromJSON, gameModeToJSON } from "./event";
 
 export const protobufPackage = "dogfight";
 
 export interface JoinRequest {
   username: string;
   roomId?: string | undefined;
+  spectate: boolean;
+  password?: string | undefined;
 }
 
 export interface JoinResponse {
   clientId: string;
   host: string;
   token: string;
+  spectator: boolean;
+}
+
+export interface ResumeRequest {
+  token: string;
+}
+
+export interface CreateRoomRequest {
+  password?: string | undefined;
+  maxPlayers: number;
+  mode: GameMode;
+  scoreLimit: number;
+  roundDuration: number;
+  worldSize: number;
+  boundary: Boundary;
+}
+
+export interface CreateRoomResponse {
+  roomId: string;
 }
 
 function createBaseJoinRequest(): JoinRequest {
-  return { username: "", roomId: undefined };
+  return { username: "", roomId: undefined, spectate: false, password: undefined };
 }
 
 export const JoinRequest: MessageFns<JoinRequest> = {
@@ -32,6 +54,12 @@ export const JoinRequest: MessageFns<JoinRequest> = {
     if (message.roomId !== undefined) {
       writer.uint32(18).string(message.roomId);
     }
+    if (message.spectate !== false) {
+      writer.uint32(24).bool(message.spectate);
+    }
+    if (message.password !== undefined) {
+      writer.uint32(34).string(message.password);
+    }
     return writer;
   },
 
@@ -58,6 +86,22 @@ export const JoinRequest: MessageFns<JoinRequest> = {
           message.roomId = reader.string();
           continue;
         }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.spectate = reader.bool();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.password = reader.string();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -71,6 +115,8 @@ export const JoinRequest: MessageFns<JoinRequest> = {
     return {
       username: isSet(object.username) ? globalThis.String(object.username) : "",
       roomId: isSet(object.roomId) ? globalThis.String(object.roomId) : undefined,
+      spectate: isSet(object.spectate) ? globalThis.Boolean(object.spectate) : false,
+      password: isSet(object.password) ? globalThis.String(object.password) : undefined,
     };
   },
 
@@ -82,6 +128,12 @@ export const JoinRequest: MessageFns<JoinRequest> = {
     if (message.roomId !== undefined) {
       obj.roomId = message.roomId;
     }
+    if (message.spectate !== false) {
+      obj.spectate = message.spectate;
+    }
+    if (message.password !== undefined) {
+      obj.password = message.password;
+    }
     return obj;
   },
 
@@ -92,12 +144,14 @@ export const JoinRequest: MessageFns<JoinRequest> = {
     const message = createBaseJoinRequest();
     message.username = object.username ?? "";
     message.roomId = object.roomId ?? undefined;
+    message.spectate = object.spectate ?? false;
+    message.password = object.password ?? undefined;
     return message;
   },
 };
 
 function createBaseJoinResponse(): JoinResponse {
-  return { clientId: "", host: "", token: "" };
+  return { clientId: "", host: "", token: "", spectator: false };
 }
 
 export const JoinResponse: MessageFns<JoinResponse> = {
@@ -111,6 +165,9 @@ export const JoinResponse: MessageFns<JoinResponse> = {
     if (message.token !== "") {
       writer.uint32(26).string(message.token);
     }
+    if (message.spectator !== false) {
+      writer.uint32(32).bool(message.spectator);
+    }
     return writer;
   },
 
@@ -145,6 +202,14 @@ export const JoinResponse: MessageFns<JoinResponse> = {
           message.token = reader.string();
           continue;
         }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.spectator = reader.bool();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -159,6 +224,7 @@ export const JoinResponse: MessageFns<JoinResponse> = {
       clientId: isSet(object.clientId) ? globalThis.String(object.clientId) : "",
       host: isSet(object.host) ? globalThis.String(object.host) : "",
       token: isSet(object.token) ? globalThis.String(object.token) : "",
+      spectator: isSet(object.spectator) ? globalThis.Boolean(object.spectator) : false,
     };
   },
 
@@ -173,6 +239,9 @@ export const JoinResponse: MessageFns<JoinResponse> = {
     if (message.token !== "") {
       obj.token = message.token;
     }
+    if (message.spectator !== false) {
+      obj.spectator = message.spectator;
+    }
     return obj;
   },
 
@@ -184,6 +253,279 @@ export const JoinResponse: MessageFns<JoinResponse> = {
     message.clientId = object.clientId ?? "";
     message.host = object.host ?? "";
     message.token = object.token ?? "";
+    message.spectator = object.spectator ?? false;
+    return message;
+  },
+};
+
+function createBaseResumeRequest(): ResumeRequest {
+  return { token: "" };
+}
+
+export const ResumeRequest: MessageFns<ResumeRequest> = {
+  encode(message: ResumeRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.token !== "") {
+      writer.uint32(10).string(message.token);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ResumeRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseResumeRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.token = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ResumeRequest {
+    return { token: isSet(object.token) ? globalThis.String(object.token) : "" };
+  },
+
+  toJSON(message: ResumeRequest): unknown {
+    const obj: any = {};
+    if (message.token !== "") {
+      obj.token = message.token;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<ResumeRequest>, I>>(base?: I): ResumeRequest {
+    return ResumeRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<ResumeRequest>, I>>(object: I): ResumeRequest {
+    const message = createBaseResumeRequest();
+    message.token = object.token ?? "";
+    return message;
+  },
+};
+
+function createBaseCreateRoomRequest(): CreateRoomRequest {
+  return { password: undefined, maxPlayers: 0, mode: 0, scoreLimit: 0, roundDuration: 0, worldSize: 0, boundary: 0 };
+}
+
+export const CreateRoomRequest: MessageFns<CreateRoomRequest> = {
+  encode(message: CreateRoomRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.password !== undefined) {
+      writer.uint32(10).string(message.password);
+    }
+    if (message.maxPlayers !== 0) {
+      writer.uint32(16).uint32(message.maxPlayers);
+    }
+    if (message.mode !== 0) {
+      writer.uint32(24).int32(message.mode);
+    }
+    if (message.scoreLimit !== 0) {
+      writer.uint32(32).uint32(message.scoreLimit);
+    }
+    if (message.roundDuration !== 0) {
+      writer.uint32(40).uint32(message.roundDuration);
+    }
+    if (message.worldSize !== 0) {
+      writer.uint32(48).uint32(message.worldSize);
+    }
+    if (message.boundary !== 0) {
+      writer.uint32(56).int32(message.boundary);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CreateRoomRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateRoomRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.password = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.maxPlayers = reader.uint32();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.mode = reader.int32() as any;
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.scoreLimit = reader.uint32();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.roundDuration = reader.uint32();
+          continue;
+        }
+        case 6: {
+          if (tag !== 48) {
+            break;
+          }
+
+          message.worldSize = reader.uint32();
+          continue;
+        }
+        case 7: {
+          if (tag !== 56) {
+            break;
+          }
+
+          message.boundary = reader.int32() as any;
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateRoomRequest {
+    return {
+      password: isSet(object.password) ? globalThis.String(object.password) : undefined,
+      maxPlayers: isSet(object.maxPlayers) ? globalThis.Number(object.maxPlayers) : 0,
+      mode: isSet(object.mode) ? gameModeFromJSON(object.mode) : 0,
+      scoreLimit: isSet(object.scoreLimit) ? globalThis.Number(object.scoreLimit) : 0,
+      roundDuration: isSet(object.roundDuration) ? globalThis.Number(object.roundDuration) : 0,
+      worldSize: isSet(object.worldSize) ? globalThis.Number(object.worldSize) : 0,
+      boundary: isSet(object.boundary) ? boundaryFromJSON(object.boundary) : 0,
+    };
+  },
+
+  toJSON(message: CreateRoomRequest): unknown {
+    const obj: any = {};
+    if (message.password !== undefined) {
+      obj.password = message.password;
+    }
+    if (message.maxPlayers !== 0) {
+      obj.maxPlayers = Math.round(message.maxPlayers);
+    }
+    if (message.mode !== 0) {
+      obj.mode = gameModeToJSON(message.mode);
+    }
+    if (message.scoreLimit !== 0) {
+      obj.scoreLimit = Math.round(message.scoreLimit);
+    }
+    if (message.roundDuration !== 0) {
+      obj.roundDuration = Math.round(message.roundDuration);
+    }
+    if (message.worldSize !== 0) {
+      obj.worldSize = Math.round(message.worldSize);
+    }
+    if (message.boundary !== 0) {
+      obj.boundary = boundaryToJSON(message.boundary);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<CreateRoomRequest>, I>>(base?: I): CreateRoomRequest {
+    return CreateRoomRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<CreateRoomRequest>, I>>(object: I): CreateRoomRequest {
+    const message = createBaseCreateRoomRequest();
+    message.password = object.password ?? undefined;
+    message.maxPlayers = object.maxPlayers ?? 0;
+    message.mode = object.mode ?? 0;
+    message.scoreLimit = object.scoreLimit ?? 0;
+    message.roundDuration = object.roundDuration ?? 0;
+    message.worldSize = object.worldSize ?? 0;
+    message.boundary = object.boundary ?? 0;
+    return message;
+  },
+};
+
+function createBaseCreateRoomResponse(): CreateRoomResponse {
+  return { roomId: "" };
+}
+
+export const CreateRoomResponse: MessageFns<CreateRoomResponse> = {
+  encode(message: CreateRoomResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.roomId !== "") {
+      writer.uint32(10).string(message.roomId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CreateRoomResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    const end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateRoomResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.roomId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateRoomResponse {
+    return { roomId: isSet(object.roomId) ? globalThis.String(object.roomId) : "" };
+  },
+
+  toJSON(message: CreateRoomResponse): unknown {
+    const obj: any = {};
+    if (message.roomId !== "") {
+      obj.roomId = message.roomId;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<CreateRoomResponse>, I>>(base?: I): CreateRoomResponse {
+    return CreateRoomResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<CreateRoomResponse>, I>>(object: I): CreateRoomResponse {
+    const message = createBaseCreateRoomResponse();
+    message.roomId = object.roomId ?? "";
     return message;
   },
 };
@@ -2,8 +2,12 @@ package main
 
 import (
 	"flag"
+	"log"
 	"server/internal/balancer"
 	"server/internal/env"
+	"server/internal/game"
+	"server/internal/room"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,9 +17,78 @@ func main() {
 	host := flag.String("host", env.GetOrDefault("HOST", "localhost"), "host")
 	port := flag.String("port", env.GetOrDefault("PORT", ":5173"), "port")
 	roomCapacity := flag.Int("room-capacity", env.GetOrDefaultInt("ROOM_CAPACITY", 16), "port")
+	strategyName := flag.String(
+		"strategy",
+		env.GetOrDefault("STRATEGY", balancer.STRATEGY_LEAST_CONNECTION),
+		"load balancing strategy (least-connection, round-robin, weighted or pack)",
+	)
+	modeName := flag.String(
+		"mode",
+		env.GetOrDefault("MODE", game.MODE_FREE_FOR_ALL),
+		"game mode of new rooms (ffa, tdm or first-to-n)",
+	)
+	scoreLimit := flag.Int(
+		"score-limit",
+		env.GetOrDefaultInt("SCORE_LIMIT", 0),
+		"kills to win a round, or 0 for the mode's default",
+	)
+	roundDuration := flag.Int(
+		"round-duration",
+		env.GetOrDefaultInt("ROUND_DURATION", 0),
+		"seconds in a round, or 0 if untimed",
+	)
+	boundaryName := flag.String(
+		"boundary",
+		env.GetOrDefault("BOUNDARY", game.BOUNDARY_WRAP),
+		"edge of the world in new rooms (wrap, bounce or zone)",
+	)
+	internalPort := flag.String(
+		"internal-port",
+		env.GetOrDefault("INTERNAL_PORT", ""),
+		"port for the internal api, or empty to share the public port",
+	)
+	reconnectGrace := flag.Int(
+		"reconnect-grace",
+		env.GetOrDefaultInt("RECONNECT_GRACE", int(room.DEFAULT_RECONNECT_GRACE.Seconds())),
+		"seconds after expiry that a token can resume a session",
+	)
 	secret := env.GetOrPanic("JWT_SECRET")
+	internalSecret := env.GetOrDefault("INTERNAL_SECRET", secret)
 	flag.Parse()
 
-	master := balancer.NewMaster(*host, *port, []byte(secret), *roomCapacity)
+	strategy, err := balancer.NewStrategy(*strategyName)
+	if err != nil {
+		log.Fatalf("could not create strategy: %v", err)
+	}
+
+	mode, err := game.ParseGameMode(*modeName)
+	if err != nil {
+		log.Fatalf("could not parse mode: %v", err)
+	}
+
+	boundary, err := game.ParseBoundary(*boundaryName)
+	if err != nil {
+		log.Fatalf("could not parse boundary: %v", err)
+	}
+
+	internal := balancer.InternalConfig{
+		Secret: []byte(internalSecret),
+		Port:   *internalPort,
+	}
+	master := balancer.NewMaster(
+		*host,
+		*port,
+		[]byte(secret),
+		internal,
+		*roomCapacity,
+		balancer.RoomConfig{
+			Mode:          mode,
+			ScoreLimit:    *scoreLimit,
+			RoundDuration: time.Duration(*roundDuration) * time.Second,
+			Boundary:      boundary,
+		},
+		strategy,
+		time.Duration(*reconnectGrace)*time.Second,
+	)
 	master.Serve()
 }
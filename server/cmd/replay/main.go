@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"server/internal/env"
+	"server/internal/game"
+	"server/internal/replay"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	godotenv.Load()
+	host := flag.String("host", env.GetOrDefault("HOST", "localhost"), "host")
+	port := flag.String("port", env.GetOrDefault("PORT", ":5173"), "port")
+	path := flag.String("file", "", "path to a recorded match")
+	flag.Parse()
+
+	events, err := game.ReadRecording(*path)
+	if err != nil {
+		log.Fatalf("could not read recording: %v", err)
+	}
+
+	server := replay.NewServer(*host, *port, events)
+	server.Serve()
+}
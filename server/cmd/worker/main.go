@@ -5,6 +5,9 @@ import (
 	"log"
 	"server/internal/balancer"
 	"server/internal/env"
+	"server/internal/game"
+	"server/internal/room"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,14 +16,79 @@ func main() {
 	godotenv.Load()
 	host := flag.String("host", env.GetOrDefault("HOST", "localhost"), "host")
 	port := flag.String("port", env.GetOrDefault("PORT", ":5174"), "port")
+	rewindWindow := flag.Int(
+		"rewind-window",
+		env.GetOrDefaultInt("REWIND_WINDOW", int(game.DEFAULT_REWIND_WINDOW.Milliseconds())),
+		"max lag compensation in milliseconds",
+	)
+	bots := flag.Int(
+		"bots",
+		env.GetOrDefaultInt("BOTS", 0),
+		"number of bots per room, which are dropped as players join",
+	)
+	idleTimeout := flag.Int(
+		"idle-timeout",
+		env.GetOrDefaultInt("IDLE_TIMEOUT", int(room.DEFAULT_IDLE_TIMEOUT.Seconds())),
+		"seconds before an empty room is closed",
+	)
+	reconnectGrace := flag.Int(
+		"reconnect-grace",
+		env.GetOrDefaultInt("RECONNECT_GRACE", int(room.DEFAULT_RECONNECT_GRACE.Seconds())),
+		"seconds a disconnected client has to reconnect",
+	)
+	recordDir := flag.String(
+		"record-dir",
+		env.GetOrDefault("RECORD_DIR", ""),
+		"directory to record matches to, or empty to disable recording",
+	)
+	capacity := flag.Int(
+		"capacity",
+		env.GetOrDefaultInt("CAPACITY", 0),
+		"max number of clients, or 0 if unlimited",
+	)
+	weight := flag.Int(
+		"weight",
+		env.GetOrDefaultInt("WEIGHT", 0),
+		"relative share of clients, or 0 to use capacity",
+	)
+	internalPort := flag.String(
+		"internal-port",
+		env.GetOrDefault("INTERNAL_PORT", ""),
+		"port for the internal api, or empty to share the public port",
+	)
+	masterUrl := flag.String(
+		"master",
+		env.GetOrDefault("MASTER_URL", "http://localhost:5173"),
+		"address of the master's internal api",
+	)
 	secret := env.GetOrPanic("JWT_SECRET")
+	internalSecret := env.GetOrDefault("INTERNAL_SECRET", secret)
 	flag.Parse()
 
-	err := balancer.RegisterWorker(*host, *port)
+	config := game.NewConfig(
+		time.Duration(*rewindWindow)*time.Millisecond,
+		*bots,
+	)
+	worker := balancer.NewWorker(
+		*host,
+		*port,
+		[]byte(secret),
+		balancer.InternalConfig{
+			Secret: []byte(internalSecret),
+			Port:   *internalPort,
+		},
+		*masterUrl,
+		config,
+		time.Duration(*idleTimeout)*time.Second,
+		time.Duration(*reconnectGrace)*time.Second,
+		*recordDir,
+		*capacity,
+		*weight,
+	)
+
+	err := worker.Register()
 	if err != nil {
 		log.Fatalf("could not register worker: %v", err)
 	}
-
-	worker := balancer.NewWorker(*host, *port, []byte(secret))
 	worker.Serve()
 }
@@ -0,0 +1,94 @@
+package balancer
+
+import "time"
+
+// A HostStatus describes whether a worker is reachable, based on the results
+// of the most recent probes.
+type HostStatus int
+
+const (
+	HOST_HEALTHY HostStatus = iota
+	HOST_SUSPECT
+	HOST_DEAD
+)
+
+const (
+	SUSPECT_THRESHOLD = 1 // consecutive failed probes before a host is suspect
+	DEAD_THRESHOLD    = 3 // consecutive failed probes before a host is dead
+
+	LOST_ROOM_TTL = 10 * time.Minute // how long a lost room is remembered
+)
+
+func (s HostStatus) String() string {
+	switch s {
+	case HOST_HEALTHY:
+		return "healthy"
+	case HOST_SUSPECT:
+		return "suspect"
+	case HOST_DEAD:
+		return "dead"
+	}
+	return "unknown"
+}
+
+// A hostHealth tracks the probe results of a single worker.
+type hostHealth struct {
+	status    HostStatus
+	failures  int       // consecutive failed probes
+	startedAt int64     // when the worker process started, in unix milliseconds
+	lastSeen  time.Time // last successful probe or heartbeat
+}
+
+func newHostHealth(startedAt int64) *hostHealth {
+	return &hostHealth{
+		status:    HOST_HEALTHY,
+		failures:  0,
+		startedAt: startedAt,
+		lastSeen:  time.Now(),
+	}
+}
+
+// recordSuccess marks the host as healthy.
+func (h *hostHealth) recordSuccess() {
+	h.status = HOST_HEALTHY
+	h.failures = 0
+	h.lastSeen = time.Now()
+}
+
+// recordFailure counts a failed probe, and returns the host's new status.
+func (h *hostHealth) recordFailure() HostStatus {
+	h.failures++
+	if h.failures >= DEAD_THRESHOLD {
+		h.status = HOST_DEAD
+	} else if h.failures >= SUSPECT_THRESHOLD {
+		h.status = HOST_SUSPECT
+	}
+	return h.status
+}
+
+// isAvailable reports whether new rooms can be assigned to the host.
+func (h *hostHealth) isAvailable() bool {
+	return h.status != HOST_DEAD
+}
+
+// A lostRoom is a room which was on a host that was considered dead. If the
+// host comes back without restarting, the room is adopted again.
+type lostRoom struct {
+	host    string
+	private *privateRoom // or nil if the room is public
+	lostAt  time.Time
+}
+
+func newLostRoom(host string, private *privateRoom) *lostRoom {
+	return &lostRoom{
+		host:    host,
+		private: private,
+		lostAt:  time.Now(),
+	}
+}
+
+// isExpired reports whether the room has been lost for longer than
+// LOST_ROOM_TTL.
+func (l *lostRoom) isExpired() bool {
+	return time.Since(l.lostAt) >= LOST_ROOM_TTL
+}
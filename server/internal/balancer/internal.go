@@ -0,0 +1,117 @@
+package balancer
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"server/internal/session"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// An InternalConfig describes how the master and workers talk to each other.
+type InternalConfig struct {
+	Secret []byte // key used to sign internal requests
+	Port   string // port for a separate internal listener, or empty to share
+}
+
+// An issuerKey is the context key of the issuer of an internal request.
+type issuerKey struct{}
+
+// authenticateInternal rejects requests which were not signed with secret for
+// this method, path and audience, or whose token has already been used. The
+// issuer of the token is added to the request context.
+func authenticateInternal(audience string, secret []byte) func(http.Handler) http.Handler {
+	used := session.NewReplayGuard()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !found {
+				http.Error(w, "missing internal token", http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := session.ParseInternalToken(
+				token,
+				audience,
+				r.Method,
+				r.URL.Path,
+				secret,
+			)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			err = used.Use(&claims.RegisteredClaims)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), issuerKey{}, claims.Issuer)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// getIssuer returns the issuer of an internal request, or an empty string if
+// the request was not authenticated.
+func getIssuer(r *http.Request) string {
+	issuer, _ := r.Context().Value(issuerKey{}).(string)
+	return issuer
+}
+
+// signRequest attaches an internal token issued by issuer to request, which
+// is only valid for its method, path and host.
+func signRequest(request *http.Request, issuer string, secret []byte) error {
+	token, err := session.CreateInternalToken(
+		issuer,
+		request.URL.Host,
+		request.Method,
+		request.URL.Path,
+		secret,
+	)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return nil
+}
+
+// serveInternal adds the internal routes to the public router r, or serves
+// them on a separate listener if config has an internal port. Requests must be
+// signed for the address they are served on.
+func serveInternal(
+	r chi.Router,
+	host string,
+	port string,
+	config InternalConfig,
+	routes func(chi.Router),
+) {
+	audience := host + cmp.Or(config.Port, port)
+	if config.Port == "" {
+		r.Group(func(r chi.Router) {
+			r.Use(authenticateInternal(audience, config.Secret))
+			routes(r)
+		})
+		return
+	}
+
+	internal := chi.NewRouter()
+	internal.Use(middleware.Logger)
+	internal.Use(authenticateInternal(audience, config.Secret))
+	routes(internal)
+
+	go func() {
+		log.Printf("internal api is running on http://%s%s", host, config.Port)
+		if err := http.ListenAndServe(config.Port, internal); err != nil {
+			log.Fatalf("failed to start internal server: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,121 @@
+package balancer
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"server/internal/session"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	TEST_AUDIENCE = "localhost:5174"
+	TEST_PATH     = "/internal/status"
+)
+
+var internalSecret = []byte("internal")
+
+// newExpiredInternalToken returns an internal token for a status request to
+// TEST_AUDIENCE, which expired a minute ago.
+func newExpiredInternalToken(t *testing.T) string {
+	issuedAt := time.Now().Add(-time.Minute)
+	claims := session.InternalClaims{
+		Method: "GET",
+		Path:   TEST_PATH,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "expired",
+			Issuer:    "localhost:5173",
+			Audience:  jwt.ClaimStrings{TEST_AUDIENCE},
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(session.INTERNAL_TOKEN_TTL)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(internalSecret)
+	if err != nil {
+		t.Fatalf("could not create token: %v", err)
+	}
+	return token
+}
+
+func TestAuthenticateInternal(t *testing.T) {
+	tests := map[string]struct {
+		method   string
+		path     string
+		audience string
+		secret   []byte
+		token    string // sent instead of signing, or "none" to send no token
+		wantCode int
+	}{
+		"Signed request": {"GET", TEST_PATH, TEST_AUDIENCE, internalSecret, "", http.StatusOK},
+		"Wrong secret":   {"GET", TEST_PATH, TEST_AUDIENCE, []byte("wrong"), "", http.StatusUnauthorized},
+		"Wrong method":   {"PUT", TEST_PATH, TEST_AUDIENCE, internalSecret, "", http.StatusUnauthorized},
+		"Wrong path":     {"GET", "/internal/create", TEST_AUDIENCE, internalSecret, "", http.StatusUnauthorized},
+		"Wrong audience": {"GET", TEST_PATH, "localhost:5175", internalSecret, "", http.StatusUnauthorized},
+		"Expired token":  {"GET", TEST_PATH, TEST_AUDIENCE, internalSecret, newExpiredInternalToken(t), http.StatusUnauthorized},
+		"Missing token":  {"GET", TEST_PATH, TEST_AUDIENCE, internalSecret, "none", http.StatusUnauthorized},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			handler := authenticateInternal(TEST_AUDIENCE, internalSecret)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+
+			// The token is signed for the test's request, but sent as a status
+			// request to TEST_AUDIENCE
+			url := fmt.Sprintf("http://%s%s", test.audience, test.path)
+			signed := httptest.NewRequest(test.method, url, nil)
+			err := signRequest(signed, "localhost:5173", test.secret)
+			if err != nil {
+				t.Fatalf("could not sign request: %v", err)
+			}
+
+			request := httptest.NewRequest("GET", "http://"+TEST_AUDIENCE+TEST_PATH, nil)
+			switch test.token {
+			case "":
+				request.Header.Set("Authorization", signed.Header.Get("Authorization"))
+			case "none":
+			default:
+				request.Header.Set("Authorization", "Bearer "+test.token)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+			if recorder.Code != test.wantCode {
+				t.Errorf("want %v but got %v", test.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestAuthenticateInternalReplay(t *testing.T) {
+	issuers := []string{}
+	handler := authenticateInternal(TEST_AUDIENCE, internalSecret)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			issuers = append(issuers, getIssuer(r))
+		}),
+	)
+
+	request := httptest.NewRequest("GET", "http://"+TEST_AUDIENCE+TEST_PATH, nil)
+	err := signRequest(request, "localhost:5173", internalSecret)
+	if err != nil {
+		t.Fatalf("could not sign request: %v", err)
+	}
+
+	want := []int{http.StatusOK, http.StatusUnauthorized}
+	for i, wantCode := range want {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if recorder.Code != wantCode {
+			t.Errorf("want %v on use %d but got %v", wantCode, i+1, recorder.Code)
+		}
+	}
+	if len(issuers) != 1 || issuers[0] != "localhost:5173" {
+		t.Errorf("want issuer [localhost:5173] but got %v", issuers)
+	}
+}
@@ -3,14 +3,16 @@ package balancer
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
-	"math"
 	"net/http"
 	"server/internal/id"
 	"server/internal/session"
 	"server/pb"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
@@ -25,6 +27,24 @@ const (
 	PROBE_INTERVAL = 60 * time.Second
 )
 
+var (
+	// ErrRoomLost is returned when joining a room whose host has died.
+	ErrRoomLost = errors.New("room lost")
+	// ErrTooManyRooms is returned when creating a private room while
+	// MAX_PRIVATE_ROOMS are already open.
+	ErrTooManyRooms = errors.New("too many private rooms")
+)
+
+// A RoomConfig stores the rules which the master creates rooms with.
+type RoomConfig struct {
+	Mode          pb.GameMode
+	ScoreLimit    int           // kills to win a round, or 0 for the mode's default
+	RoundDuration time.Duration // length of a round, or 0 if untimed
+	MaxPlayers    int           // or 0 to only be limited by room capacity
+	WorldSize     int           // or 0 for the default
+	Boundary      pb.Boundary
+}
+
 func NewRegisterRequest(host string) *pb.RegisterRequest {
 	return &pb.RegisterRequest{
 		Host: host,
@@ -35,14 +55,26 @@ type Master struct {
 	host string
 	port string
 
-	client http.Client
-	secret []byte
+	client   http.Client
+	secret   []byte
+	internal InternalConfig
 
 	roomCapacity        int // max number of clients that can be assigned
+	roomConfig          RoomConfig
+	reconnectGrace      time.Duration // how long resume tokens stay valid after expiry
+	strategy            Strategy
 	hostOccupancies     map[string]int
+	hostCapacities      map[string]int    // declared max number of clients
+	hostWeights         map[string]int    // declared relative share of clients
+	hostAddresses       map[string]string // mapping of host to internal api address
 	roomOccupancies     map[string]int
 	hostToRoomsRegistry map[string][]string // mapping of host to room IDs
 	roomToHostRegistry  map[string]string   // mapping of room ID to host
+	hostHealths         map[string]*hostHealth
+	lostRooms           map[string]*lostRoom // rooms which were on dead hosts
+	privateRooms        map[string]*privateRoom
+	reservations        map[string]int // rooms being created on each host
+	privateReservations int            // private rooms being created
 
 	mu     sync.Mutex
 	ctx    context.Context
@@ -53,7 +85,11 @@ func NewMaster(
 	host string,
 	port string,
 	secret []byte,
+	internal InternalConfig,
 	roomCapacity int,
+	roomConfig RoomConfig,
+	strategy Strategy,
+	reconnectGrace time.Duration,
 ) *Master {
 	client := http.Client{Timeout: HTTP_TIMEOUT}
 	ctx, cancel := context.WithCancel(context.Background())
@@ -63,11 +99,22 @@ func NewMaster(
 		port:                port,
 		client:              client,
 		secret:              secret,
+		internal:            internal,
 		roomCapacity:        roomCapacity,
+		roomConfig:          roomConfig,
+		reconnectGrace:      reconnectGrace,
+		strategy:            strategy,
 		hostOccupancies:     map[string]int{},
+		hostCapacities:      map[string]int{},
+		hostWeights:         map[string]int{},
+		hostAddresses:       map[string]string{},
 		roomOccupancies:     map[string]int{},
 		hostToRoomsRegistry: map[string][]string{},
 		roomToHostRegistry:  map[string]string{},
+		hostHealths:         map[string]*hostHealth{},
+		lostRooms:           map[string]*lostRoom{},
+		privateRooms:        map[string]*privateRoom{},
+		reservations:        map[string]int{},
 		mu:                  sync.Mutex{},
 		ctx:                 ctx,
 		cancel:              cancel,
@@ -91,7 +138,11 @@ func (m *Master) Serve() {
 	r.Handle("/*", fs)
 
 	r.Post("/api/join", m.HandleJoin)
-	r.Put("/internal/register", m.HandleRegister)
+	r.Post("/api/resume", m.HandleResume)
+	r.Post("/api/room", m.HandleCreateRoom)
+	serveInternal(r, m.host, m.port, m.internal, func(r chi.Router) {
+		r.Put("/internal/register", m.HandleRegister)
+	})
 
 	go m.probeWorkers()
 
@@ -101,6 +152,11 @@ func (m *Master) Serve() {
 	}
 }
 
+// HandleRegister registers a worker. Workers periodically register again as a
+// heartbeat. If the worker has restarted since it last registered, its
+// previous rooms are dropped and it is registered afresh. If it was considered
+// dead without restarting, it is probed straight away so that its rooms are
+// adopted again.
 func (m *Master) HandleRegister(w http.ResponseWriter, r *http.Request) {
 	data, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -115,11 +171,38 @@ func (m *Master) HandleRegister(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Workers can only register themselves
 	host := request.Host + request.Port
+	if issuer := getIssuer(r); issuer != host {
+		http.Error(w, fmt.Sprintf("%s cannot register %s", issuer, host), http.StatusForbidden)
+		return
+	}
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.hostCapacities[host] = int(request.Capacity)
+	m.hostWeights[host] = int(request.Weight)
+	m.hostAddresses[host] = host
+	if request.InternalPort != "" {
+		m.hostAddresses[host] = request.Host + request.InternalPort
+	}
+
+	health, found := m.hostHealths[host]
+	if found && health.startedAt == request.StartedAt {
+		if !health.isAvailable() {
+			log.Printf("%s is back, adopting its rooms", host)
+			go m.probe(host)
+		}
+		health.recordSuccess()
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if found {
+		m.dropHost(host)
+	}
+
+	m.hostHealths[host] = newHostHealth(request.StartedAt)
 	m.hostOccupancies[host] = 0
 	log.Printf("registered %s", host)
 	w.WriteHeader(http.StatusCreated)
@@ -139,7 +222,17 @@ func (m *Master) HandleJoin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Spectators can only watch an existing room
+	if request.Spectate && request.RoomId == nil {
+		http.Error(w, "spectators must choose a room", http.StatusBadRequest)
+		return
+	}
+
 	host, roomId, err := m.getHost(request.RoomId)
+	if errors.Is(err, ErrRoomLost) {
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusNotFound)
 		return
@@ -147,15 +240,29 @@ func (m *Master) HandleJoin(w http.ResponseWriter, r *http.Request) {
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if m.roomOccupancies[roomId] >= m.roomCapacity {
-		http.Error(w, fmt.Sprintf("room %s is full", roomId), http.StatusConflict)
-		return
+
+	capacity := m.roomCapacity
+	private, isPrivate := m.privateRooms[roomId]
+	if isPrivate {
+		if !private.checkPassword(request.GetPassword()) {
+			http.Error(w, "incorrect password", http.StatusForbidden)
+			return
+		}
+		capacity = private.maxPlayers
 	}
 
-	// These occupancies will desync when players leave the room,
-	// but will be synced again through periodic status probes
-	m.hostOccupancies[host]++
-	m.roomOccupancies[roomId]++
+	// Spectators do not take up space in the room
+	if !request.Spectate {
+		if m.roomOccupancies[roomId] >= capacity {
+			http.Error(w, fmt.Sprintf("room %s is full", roomId), http.StatusConflict)
+			return
+		}
+
+		// These occupancies will desync when players leave the room,
+		// but will be synced again through periodic status probes
+		m.hostOccupancies[host]++
+		m.roomOccupancies[roomId]++
+	}
 
 	clientId, err := id.NewShortId()
 	if err != nil {
@@ -163,16 +270,97 @@ func (m *Master) HandleJoin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := session.CreateToken(clientId, request.Username, roomId, m.secret)
+	token, err := session.CreateToken(
+		clientId,
+		request.Username,
+		roomId,
+		host,
+		request.Spectate,
+		false,
+		m.secret,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	body, err := proto.Marshal(&pb.JoinResponse{
+		ClientId:  clientId,
+		Host:      host,
+		Token:     token,
+		Spectator: request.Spectate,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if _, err = w.Write(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleResume issues a new token for a client whose connection dropped, so
+// that it can reconnect to its room as the same client. Only tokens which
+// expired within the reconnect grace period can be resumed, so clients refresh
+// their token through HandleResume while connected. The worker only accepts
+// the new token if the room still lists the client as disconnected, so it
+// cannot be used to take over a live session.
+func (m *Master) HandleResume(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var request pb.ResumeRequest
+	err = proto.Unmarshal(data, &request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	claims, err := session.ParseResumableToken(
+		request.Token,
+		m.secret,
+		m.reconnectGrace,
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	host, roomId, err := m.lookupHost(claims.RoomId)
+	if errors.Is(err, ErrRoomLost) {
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	token, err := session.CreateToken(
+		claims.ClientId,
+		claims.Username,
+		roomId,
+		host,
+		claims.IsSpectator,
+		true,
+		m.secret,
+	)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	body, err := proto.Marshal(&pb.JoinResponse{
-		ClientId: clientId,
-		Host:     host,
-		Token:    token,
+		ClientId:  claims.ClientId,
+		Host:      host,
+		Token:     token,
+		Spectator: claims.IsSpectator,
 	})
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -198,6 +386,10 @@ func (m *Master) lookupHost(roomId string) (string, string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if lost, found := m.lostRooms[roomId]; found && !lost.isExpired() {
+		return "", "", fmt.Errorf("%w: host of room %s is down", ErrRoomLost, roomId)
+	}
+
 	host, found := m.roomToHostRegistry[roomId]
 	if !found {
 		return "", "", fmt.Errorf("room %s not found", roomId)
@@ -207,75 +399,162 @@ func (m *Master) lookupHost(roomId string) (string, string, error) {
 
 func (m *Master) assignHost() (string, string, error) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	// Look for any available rooms
+	// Look for any available public rooms
 	for roomId, host := range m.roomToHostRegistry {
+		if !m.isAvailable(host) {
+			continue
+		}
+		if _, isPrivate := m.privateRooms[roomId]; isPrivate {
+			continue
+		}
 		if m.roomOccupancies[roomId] < int(m.roomCapacity) {
+			m.mu.Unlock()
 			return host, roomId, nil
 		}
 	}
+	m.mu.Unlock()
 
 	// Create a new room if there are no available rooms
+	return m.openRoom(m.roomConfig, nil)
+}
+
+// openRoom creates a new room which follows config on a host chosen by the
+// master's strategy, and returns the host and room ID. The room is private if
+// private is not nil. The host is reserved while the worker creates the room,
+// so that the lock is not held during the request.
+func (m *Master) openRoom(config RoomConfig, private *privateRoom) (string, string, error) {
 	roomId, err := id.NewShortId()
 	if err != nil {
 		return "", "", err
 	}
 
-	host, err := m.chooseHost()
+	m.mu.Lock()
+	host, err := m.reserveHost(private != nil)
+	address := m.hostAddresses[host]
+	m.mu.Unlock()
 	if err != nil {
 		return "", "", err
 	}
 
-	err = m.createRoom(host, roomId)
+	err = m.createRoom(address, roomId, config)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.releaseHost(host, private != nil)
 	if err != nil {
 		return "", "", err
 	}
 
+	// The host may have been dropped while the room was being created
+	if !m.isAvailable(host) {
+		m.lostRooms[roomId] = newLostRoom(host, private)
+		return "", "", fmt.Errorf("%w: host %s went down", ErrRoomLost, host)
+	}
+
 	m.roomToHostRegistry[roomId] = host
 	m.hostToRoomsRegistry[host] = append(m.hostToRoomsRegistry[host], roomId)
+	if private != nil {
+		m.privateRooms[roomId] = private
+	}
 	return host, roomId, nil
 }
 
-func (m *Master) createRoom(host string, roomId string) error {
+// reserveHost chooses a host for a new room, and counts the room against it
+// until releaseHost is called. Private rooms are also counted against
+// MAX_PRIVATE_ROOMS.
+func (m *Master) reserveHost(isPrivate bool) (string, error) {
+	if isPrivate {
+		if len(m.privateRooms)+m.privateReservations >= MAX_PRIVATE_ROOMS {
+			return "", ErrTooManyRooms
+		}
+	}
+
+	host, err := m.chooseHost()
+	if err != nil {
+		return "", err
+	}
+
+	m.reservations[host]++
+	if isPrivate {
+		m.privateReservations++
+	}
+	return host, nil
+}
+
+// releaseHost removes a reservation made by reserveHost.
+func (m *Master) releaseHost(host string, isPrivate bool) {
+	m.reservations[host]--
+	if m.reservations[host] == 0 {
+		delete(m.reservations, host)
+	}
+	if isPrivate {
+		m.privateReservations--
+	}
+}
+
+// createRoom asks the worker at address to create a room with roomId, which
+// follows the rules in config.
+func (m *Master) createRoom(address string, roomId string, config RoomConfig) error {
 	body, err := proto.Marshal(&pb.CreateRequest{
-		RoomId: roomId,
+		RoomId:        roomId,
+		Mode:          config.Mode,
+		ScoreLimit:    uint32(config.ScoreLimit),
+		RoundDuration: uint32(config.RoundDuration.Seconds()),
+		MaxPlayers:    uint32(config.MaxPlayers),
+		WorldSize:     uint32(config.WorldSize),
+		Boundary:      config.Boundary,
 	})
 	if err != nil {
 		return err
 	}
 
-	url := fmt.Sprintf("http://%s/internal/create", host)
+	url := fmt.Sprintf("http://%s/internal/create", address)
 	request, err := http.NewRequest("PUT", url, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
+	err = signRequest(request, m.host+m.port, m.internal.Secret)
+	if err != nil {
+		return err
+	}
 
 	response, err := m.client.Do(request)
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
 	if response.StatusCode != http.StatusCreated {
 		return fmt.Errorf("unexpected status code %v", response.StatusCode)
 	}
 	return nil
 }
 
+// chooseHost uses the master's strategy to choose a host for a new room,
+// from the hosts which are available and have space for another room. Rooms
+// which are still being created count as full.
 func (m *Master) chooseHost() (string, error) {
-	// Least connection
-	var chosen *string = nil
-	least := math.MaxInt
+	loads := []HostLoad{}
 	for host, occupancy := range m.hostOccupancies {
-		if occupancy < least {
-			chosen = &host
-			least = occupancy
+		if !m.isAvailable(host) {
+			continue
 		}
-	}
 
-	if chosen == nil {
-		return "", fmt.Errorf("no hosts available")
+		load := HostLoad{
+			Host:      host,
+			Occupancy: occupancy + m.reservations[host]*m.roomCapacity,
+			Capacity:  m.hostCapacities[host],
+			Weight:    m.hostWeights[host],
+		}
+		if load.hasSpace(m.roomCapacity) {
+			loads = append(loads, load)
+		}
 	}
-	return *chosen, nil
+	sort.Slice(loads, func(i, j int) bool {
+		return loads[i].Host < loads[j].Host
+	})
+
+	return m.strategy.Choose(loads)
 }
 
 func (m *Master) probeWorkers() {
@@ -289,30 +568,47 @@ func (m *Master) probeWorkers() {
 
 		case <-ticker.C:
 			// Probe workers asynchronously
-			for host := range m.hostOccupancies {
+			for _, host := range m.getAvailableHosts() {
 				go m.probe(host)
 			}
+			m.pruneLostRooms()
 		}
 	}
 }
 
 func (m *Master) probe(host string) {
-	url := fmt.Sprintf("http://%s/internal/status", host)
+	m.mu.Lock()
+	url := fmt.Sprintf("http://%s/internal/status", m.hostAddresses[host])
+	m.mu.Unlock()
+
 	request, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		log.Printf("failed to get status from %s", host)
 		return
 	}
+	err = signRequest(request, m.host+m.port, m.internal.Secret)
+	if err != nil {
+		log.Printf("failed to sign status request to %s", host)
+		return
+	}
 
 	response, err := m.client.Do(request)
-	if err != nil || response.StatusCode != http.StatusOK {
+	if err != nil {
 		log.Printf("failed to get status from %s", host)
+		m.recordProbeFailure(host)
+		return
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		log.Printf("failed to get status from %s", host)
+		m.recordProbeFailure(host)
 		return
 	}
 
 	data, err := io.ReadAll(response.Body)
 	if err != nil {
 		log.Printf("failed to read status from %s", host)
+		m.recordProbeFailure(host)
 		return
 	}
 
@@ -320,17 +616,136 @@ func (m *Master) probe(host string) {
 	err = proto.Unmarshal(data, &body)
 	if err != nil {
 		log.Printf("failed to parse status from %s", host)
+		m.recordProbeFailure(host)
 		return
 	}
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if health, found := m.hostHealths[host]; found {
+		health.recordSuccess()
+	}
+
+	for _, roomId := range body.ClosedRoomIds {
+		m.removeRoom(host, roomId)
+	}
+
 	// Overwrite occupancies with the most recent status
 	hostOccupancy := 0
 	for _, roomStatus := range body.RoomStatuses {
+		if !m.adoptRoom(host, roomStatus.RoomId) {
+			continue
+		}
 		m.roomOccupancies[roomStatus.RoomId] = int(roomStatus.Occupancy)
 		hostOccupancy += int(roomStatus.Occupancy)
 	}
 	m.hostOccupancies[host] = hostOccupancy
 }
+
+// adoptRoom reports whether host is known to run the room with roomId. Rooms
+// which were lost when host was considered dead are registered again, but
+// rooms the master did not know about are ignored.
+func (m *Master) adoptRoom(host string, roomId string) bool {
+	if m.roomToHostRegistry[roomId] == host {
+		return true
+	}
+
+	lost, found := m.lostRooms[roomId]
+	if !found || lost.host != host {
+		return false
+	}
+
+	delete(m.lostRooms, roomId)
+	m.roomToHostRegistry[roomId] = host
+	m.hostToRoomsRegistry[host] = append(m.hostToRoomsRegistry[host], roomId)
+	if lost.private != nil {
+		m.privateRooms[roomId] = lost.private
+	}
+	log.Printf("adopted room %s on %s", roomId, host)
+	return true
+}
+
+// pruneLostRooms forgets rooms which have been lost for longer than
+// LOST_ROOM_TTL.
+func (m *Master) pruneLostRooms() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for roomId, lost := range m.lostRooms {
+		if lost.isExpired() {
+			delete(m.lostRooms, roomId)
+		}
+	}
+}
+
+// removeRoom removes a room which is no longer running on host from the
+// registries. Workers report closed rooms more than once, so rooms which are
+// not registered on host are ignored.
+func (m *Master) removeRoom(host string, roomId string) {
+	if m.roomToHostRegistry[roomId] != host {
+		return
+	}
+	delete(m.roomToHostRegistry, roomId)
+	delete(m.roomOccupancies, roomId)
+	delete(m.privateRooms, roomId)
+	m.hostToRoomsRegistry[host] = slices.DeleteFunc(
+		m.hostToRoomsRegistry[host],
+		func(id string) bool {
+			return id == roomId
+		},
+	)
+	log.Printf("removed room %s on %s", roomId, host)
+}
+
+// recordProbeFailure counts a failed probe against host. Once host is
+// considered dead, its rooms are dropped.
+func (m *Master) recordProbeFailure(host string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	health, found := m.hostHealths[host]
+	if !found || !health.isAvailable() {
+		return
+	}
+
+	status := health.recordFailure()
+	log.Printf("%s is %v after %d failed probes", host, status, health.failures)
+	if status == HOST_DEAD {
+		m.dropHost(host)
+	}
+}
+
+// dropHost removes all rooms on host from the registries. Later attempts to
+// join these rooms fail with ErrRoomLost.
+func (m *Master) dropHost(host string) {
+	for _, roomId := range m.hostToRoomsRegistry[host] {
+		m.lostRooms[roomId] = newLostRoom(host, m.privateRooms[roomId])
+		delete(m.roomToHostRegistry, roomId)
+		delete(m.roomOccupancies, roomId)
+		delete(m.privateRooms, roomId)
+	}
+	delete(m.hostToRoomsRegistry, host)
+	m.hostOccupancies[host] = 0
+	log.Printf("dropped rooms on %s", host)
+}
+
+// isAvailable reports whether host can be assigned new clients.
+func (m *Master) isAvailable(host string) bool {
+	health, found := m.hostHealths[host]
+	return found && health.isAvailable()
+}
+
+// getAvailableHosts returns the hosts which are not dead.
+func (m *Master) getAvailableHosts() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hosts := []string{}
+	for host := range m.hostOccupancies {
+		if m.isAvailable(host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
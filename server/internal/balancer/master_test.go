@@ -0,0 +1,139 @@
+package balancer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"server/pb"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestLookupLostRoom(t *testing.T) {
+	tests := map[string]struct {
+		lostFor time.Duration
+		wantErr error
+	}{
+		"Recently lost": {time.Minute, ErrRoomLost},
+		"Lost too long": {LOST_ROOM_TTL, nil},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			master, _ := newTestMaster(t, 0, false)
+			master.lostRooms["room"] = newLostRoom(TEST_HOST, nil)
+			master.lostRooms["room"].lostAt = time.Now().Add(-test.lostFor)
+
+			_, _, err := master.lookupHost("room")
+			if err == nil {
+				t.Fatalf("want error but got nil")
+			}
+			if errors.Is(err, ErrRoomLost) != (test.wantErr != nil) {
+				t.Errorf("want %v but got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAdoptRoom(t *testing.T) {
+	private := newPrivateRoom(nil, 4)
+	tests := map[string]struct {
+		host        string
+		roomId      string
+		want        bool
+		wantPrivate bool
+	}{
+		"Tracked room":             {TEST_HOST, "tracked", true, false},
+		"Lost public room":         {TEST_HOST, "public", true, false},
+		"Lost private room":        {TEST_HOST, "private", true, true},
+		"Lost on a different host": {"other:8080", "public", false, false},
+		"Unknown room":             {TEST_HOST, "unknown", false, false},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			master, _ := newTestMaster(t, 0, false)
+			master.hostToRoomsRegistry[TEST_HOST] = []string{"public", "private"}
+			master.privateRooms["private"] = private
+			master.dropHost(TEST_HOST)
+			master.roomToHostRegistry["tracked"] = TEST_HOST
+
+			got := master.adoptRoom(test.host, test.roomId)
+			if got != test.want {
+				t.Fatalf("want %v but got %v", test.want, got)
+			}
+
+			_, _, err := master.lookupHost(test.roomId)
+			if test.want && err != nil {
+				t.Errorf("want room to be registered but got %v", err)
+			}
+			gotPrivate := master.privateRooms[test.roomId] == private
+			if gotPrivate != test.wantPrivate {
+				t.Errorf("want private %v but got %v", test.wantPrivate, gotPrivate)
+			}
+		})
+	}
+}
+
+func TestHandleRegister(t *testing.T) {
+	tests := map[string]struct {
+		issuer   string
+		wantCode int
+	}{
+		"Worker registers itself":      {"localhost:5174", http.StatusCreated},
+		"Worker registers another":     {"localhost:5175", http.StatusForbidden},
+		"Unauthenticated registration": {"", http.StatusForbidden},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			master, _ := newTestMaster(t, 0, false)
+			body, _ := proto.Marshal(&pb.RegisterRequest{Host: "localhost", Port: ":5174"})
+			request := httptest.NewRequest("PUT", "/internal/register", bytes.NewBuffer(body))
+			ctx := context.WithValue(request.Context(), issuerKey{}, test.issuer)
+
+			recorder := httptest.NewRecorder()
+			master.HandleRegister(recorder, request.WithContext(ctx))
+			if recorder.Code != test.wantCode {
+				t.Errorf("want %v but got %v", test.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestProbe(t *testing.T) {
+	status, _ := proto.Marshal(&pb.StatusResponse{})
+	tests := map[string]struct {
+		code         int
+		body         []byte
+		wantFailures int
+	}{
+		"Healthy worker":     {http.StatusOK, status, 0},
+		"Failing worker":     {http.StatusInternalServerError, nil, 1},
+		"Undecodable status": {http.StatusOK, []byte{0xff, 0xff}, 1},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			worker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.code)
+				w.Write(test.body)
+			}))
+			t.Cleanup(worker.Close)
+
+			master, _ := newTestMaster(t, 0, false)
+			master.hostAddresses[TEST_HOST] = strings.TrimPrefix(worker.URL, "http://")
+			master.probe(TEST_HOST)
+
+			got := master.hostHealths[TEST_HOST].failures
+			if got != test.wantFailures {
+				t.Errorf("want %v but got %v", test.wantFailures, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package balancer
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"server/internal/game"
+	"server/pb"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	MIN_WORLD_SIZE    = 2000
+	MAX_WORLD_SIZE    = 40000
+	MAX_PRIVATE_ROOMS = 256 // open at once, across all workers
+)
+
+// A privateRoom is a room created through the create-room API. Clients can
+// only join a private room with its ID, and matchmaking never fills it.
+type privateRoom struct {
+	passwordHash []byte // or nil if the room has no password
+	maxPlayers   int
+}
+
+func newPrivateRoom(password *string, maxPlayers int) *privateRoom {
+	var passwordHash []byte = nil
+	if password != nil && *password != "" {
+		hash := sha256.Sum256([]byte(*password))
+		passwordHash = hash[:]
+	}
+
+	return &privateRoom{
+		passwordHash: passwordHash,
+		maxPlayers:   maxPlayers,
+	}
+}
+
+// checkPassword reports whether password opens the room.
+func (p *privateRoom) checkPassword(password string) bool {
+	if p.passwordHash == nil {
+		return true
+	}
+	hash := sha256.Sum256([]byte(password))
+	return subtle.ConstantTimeCompare(hash[:], p.passwordHash) == 1
+}
+
+// HandleCreateRoom creates a private room with the requested settings, and
+// responds with its ID. The ID doubles as the room's invite code. At most
+// MAX_PRIVATE_ROOMS can be open at once.
+func (m *Master) HandleCreateRoom(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var request pb.CreateRoomRequest
+	err = proto.Unmarshal(data, &request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	config, err := m.newPrivateRoomConfig(&request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	private := newPrivateRoom(request.Password, config.MaxPlayers)
+	_, roomId, err := m.openRoom(config, private)
+	if errors.Is(err, ErrTooManyRooms) {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := proto.Marshal(&pb.CreateRoomResponse{
+		RoomId: roomId,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusCreated)
+	if _, err = w.Write(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// newPrivateRoomConfig validates the settings in request.
+func (m *Master) newPrivateRoomConfig(request *pb.CreateRoomRequest) (RoomConfig, error) {
+	maxPlayers := cmp.Or(int(request.MaxPlayers), m.roomCapacity)
+	if maxPlayers > m.roomCapacity {
+		return RoomConfig{}, fmt.Errorf("rooms have at most %d players", m.roomCapacity)
+	}
+
+	worldSize := cmp.Or(int(request.WorldSize), game.DEFAULT_WORLD_SIZE)
+	if worldSize < MIN_WORLD_SIZE || worldSize > MAX_WORLD_SIZE {
+		return RoomConfig{}, fmt.Errorf(
+			"world size must be between %d and %d",
+			MIN_WORLD_SIZE,
+			MAX_WORLD_SIZE,
+		)
+	}
+
+	_, err := game.NewGameMode(request.Mode, int(request.ScoreLimit))
+	if err != nil {
+		return RoomConfig{}, err
+	}
+
+	_, err = game.NewBoundary(request.Boundary)
+	if err != nil {
+		return RoomConfig{}, err
+	}
+
+	return RoomConfig{
+		Mode:          request.Mode,
+		ScoreLimit:    int(request.ScoreLimit),
+		RoundDuration: time.Duration(request.RoundDuration) * time.Second,
+		MaxPlayers:    maxPlayers,
+		WorldSize:     worldSize,
+		Boundary:      request.Boundary,
+	}, nil
+}
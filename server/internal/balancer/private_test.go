@@ -0,0 +1,171 @@
+package balancer
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"server/pb"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewPrivateRoomConfig(t *testing.T) {
+	master := &Master{roomCapacity: 16}
+	tests := map[string]struct {
+		request *pb.CreateRoomRequest
+		wantErr bool
+	}{
+		"Defaults are valid":       {&pb.CreateRoomRequest{}, false},
+		"Too many players":         {&pb.CreateRoomRequest{MaxPlayers: 17}, true},
+		"World is too small":       {&pb.CreateRoomRequest{WorldSize: MIN_WORLD_SIZE - 1}, true},
+		"World is too large":       {&pb.CreateRoomRequest{WorldSize: MAX_WORLD_SIZE + 1}, true},
+		"Unknown boundary":         {&pb.CreateRoomRequest{Boundary: 99}, true},
+		"Unknown game mode":        {&pb.CreateRoomRequest{Mode: 99}, true},
+		"Team deathmatch is valid": {&pb.CreateRoomRequest{Mode: pb.GameMode_GAME_MODE_TEAM_DEATHMATCH}, false},
+		"Smaller room is valid":    {&pb.CreateRoomRequest{MaxPlayers: 4}, false},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			_, err := master.newPrivateRoomConfig(test.request)
+			if (err != nil) != test.wantErr {
+				t.Errorf("want error %v but got %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	password := "hunter2"
+	empty := ""
+	tests := map[string]struct {
+		room     *privateRoom
+		password string
+		want     bool
+	}{
+		"Correct password":   {newPrivateRoom(&password, 0), "hunter2", true},
+		"Incorrect password": {newPrivateRoom(&password, 0), "hunter3", false},
+		"Missing password":   {newPrivateRoom(&password, 0), "", false},
+		"No password":        {newPrivateRoom(nil, 0), "anything", true},
+		"Empty password":     {newPrivateRoom(&empty, 0), "", true},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			got := test.room.checkPassword(test.password)
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestOpenRoom(t *testing.T) {
+	tests := map[string]struct {
+		private  *privateRoom
+		reserved int
+		wantErr  error
+	}{
+		"Public room":                 {nil, 0, nil},
+		"Private room":                {newPrivateRoom(nil, 0), 0, nil},
+		"Too many private rooms":      {newPrivateRoom(nil, 0), MAX_PRIVATE_ROOMS, ErrTooManyRooms},
+		"Public room ignores the cap": {nil, MAX_PRIVATE_ROOMS, nil},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			master, _ := newTestMaster(t, http.StatusCreated, false)
+			master.privateReservations = test.reserved
+
+			_, roomId, err := master.openRoom(RoomConfig{}, test.private)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("want %v but got %v", test.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			got := master.privateRooms[roomId] != nil
+			if got != (test.private != nil) {
+				t.Errorf("want private %v but got %v", test.private != nil, got)
+			}
+			if len(master.reservations) != 0 {
+				t.Errorf("want no reservations but got %v", master.reservations)
+			}
+		})
+	}
+}
+
+func TestOpenRoomUnlocked(t *testing.T) {
+	master, requested := newTestMaster(t, http.StatusCreated, true)
+
+	done := make(chan error)
+	go func() {
+		_, _, err := master.openRoom(RoomConfig{}, nil)
+		done <- err
+	}()
+
+	// The worker is blocked on the request, so the lock must be free
+	release := <-requested
+	if !master.mu.TryLock() {
+		t.Fatalf("want lock to be free during the request")
+	}
+	if master.reservations[TEST_HOST] != 1 {
+		t.Errorf("want 1 reservation but got %d", master.reservations[TEST_HOST])
+	}
+	master.mu.Unlock()
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("want no error but got %v", err)
+	}
+}
+
+func TestOpenRoomRollback(t *testing.T) {
+	master, _ := newTestMaster(t, http.StatusInternalServerError, false)
+
+	_, _, err := master.openRoom(RoomConfig{}, newPrivateRoom(nil, 0))
+	if err == nil {
+		t.Fatalf("want error but got nil")
+	}
+	if len(master.reservations) != 0 || master.privateReservations != 0 {
+		t.Errorf("want no reservations but got %v", master.reservations)
+	}
+	if len(master.roomToHostRegistry) != 0 {
+		t.Errorf("want no rooms but got %v", master.roomToHostRegistry)
+	}
+}
+
+const TEST_HOST = "worker:8080"
+
+// newTestMaster returns a Master with a single worker, which responds to
+// create requests with status. If isBlocking, each request sends a channel on
+// requested, and the response is held until that channel is closed.
+func newTestMaster(t *testing.T, status int, isBlocking bool) (*Master, chan chan struct{}) {
+	requested := make(chan chan struct{})
+	worker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isBlocking {
+			release := make(chan struct{})
+			requested <- release
+			<-release
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(worker.Close)
+
+	master := NewMaster(
+		"localhost",
+		":5173",
+		[]byte("secret"),
+		InternalConfig{Secret: []byte("internal")},
+		16,
+		RoomConfig{},
+		&LeastConnectionStrategy{},
+		time.Second,
+	)
+	master.hostOccupancies[TEST_HOST] = 0
+	master.hostHealths[TEST_HOST] = newHostHealth(0)
+	master.hostAddresses[TEST_HOST] = strings.TrimPrefix(worker.URL, "http://")
+	return master, requested
+}
@@ -0,0 +1,124 @@
+package balancer
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	STRATEGY_LEAST_CONNECTION = "least-connection"
+	STRATEGY_ROUND_ROBIN      = "round-robin"
+	STRATEGY_WEIGHTED         = "weighted"
+	STRATEGY_PACK             = "pack"
+)
+
+// A HostLoad describes the current load on a worker, and how much load it
+// declared it can take.
+type HostLoad struct {
+	Host      string
+	Occupancy int // number of assigned clients
+	Capacity  int // max number of clients, or 0 if unlimited
+	Weight    int // relative share of clients, or 0 to use the capacity
+}
+
+// hasSpace reports whether the host can take another room of roomCapacity
+// clients without exceeding its capacity.
+func (l HostLoad) hasSpace(roomCapacity int) bool {
+	return l.Capacity == 0 || l.Occupancy+roomCapacity <= l.Capacity
+}
+
+// getWeight returns the declared weight, falling back to the declared
+// capacity, and then to 1.
+func (l HostLoad) getWeight() int {
+	if l.Weight > 0 {
+		return l.Weight
+	}
+	if l.Capacity > 0 {
+		return l.Capacity
+	}
+	return 1
+}
+
+// A Strategy chooses which host a new room should be created on. loads only
+// contains hosts which are available and have space for another room, and is
+// sorted by host.
+type Strategy interface {
+	Choose(loads []HostLoad) (string, error)
+}
+
+// NewStrategy returns the Strategy with name.
+func NewStrategy(name string) (Strategy, error) {
+	switch name {
+	case STRATEGY_LEAST_CONNECTION:
+		return &LeastConnectionStrategy{}, nil
+	case STRATEGY_ROUND_ROBIN:
+		return &RoundRobinStrategy{}, nil
+	case STRATEGY_WEIGHTED:
+		return &WeightedStrategy{}, nil
+	case STRATEGY_PACK:
+		return &PackStrategy{}, nil
+	}
+	return nil, fmt.Errorf("unknown strategy %s", name)
+}
+
+// A LeastConnectionStrategy chooses the host with the fewest clients.
+type LeastConnectionStrategy struct{}
+
+func (s *LeastConnectionStrategy) Choose(loads []HostLoad) (string, error) {
+	return chooseMin(loads, func(l HostLoad) float64 {
+		return float64(l.Occupancy)
+	})
+}
+
+// A RoundRobinStrategy chooses each host in turn.
+type RoundRobinStrategy struct {
+	next int
+}
+
+func (s *RoundRobinStrategy) Choose(loads []HostLoad) (string, error) {
+	if len(loads) == 0 {
+		return "", fmt.Errorf("no hosts available")
+	}
+
+	chosen := loads[s.next%len(loads)]
+	s.next = (s.next + 1) % len(loads)
+	return chosen.Host, nil
+}
+
+// A WeightedStrategy chooses the host with the fewest clients relative to its
+// weight, so that larger hosts take proportionally more clients.
+type WeightedStrategy struct{}
+
+func (s *WeightedStrategy) Choose(loads []HostLoad) (string, error) {
+	return chooseMin(loads, func(l HostLoad) float64 {
+		return float64(l.Occupancy) / float64(l.getWeight())
+	})
+}
+
+// A PackStrategy chooses the host with the most clients, so that rooms are
+// packed onto as few hosts as possible.
+type PackStrategy struct{}
+
+func (s *PackStrategy) Choose(loads []HostLoad) (string, error) {
+	return chooseMin(loads, func(l HostLoad) float64 {
+		return -float64(l.Occupancy)
+	})
+}
+
+// chooseMin returns the host with the lowest cost. Ties are broken by the
+// order of loads.
+func chooseMin(loads []HostLoad, cost func(HostLoad) float64) (string, error) {
+	var chosen *string = nil
+	least := math.Inf(1)
+	for _, load := range loads {
+		if c := cost(load); c < least {
+			chosen = &load.Host
+			least = c
+		}
+	}
+
+	if chosen == nil {
+		return "", fmt.Errorf("no hosts available")
+	}
+	return *chosen, nil
+}
@@ -0,0 +1,82 @@
+package balancer
+
+import (
+	"testing"
+)
+
+var loads = []HostLoad{
+	{Host: "a", Occupancy: 8, Capacity: 16, Weight: 0},
+	{Host: "b", Occupancy: 12, Capacity: 64, Weight: 0},
+	{Host: "c", Occupancy: 4, Capacity: 0, Weight: 1},
+}
+
+func TestChoose(t *testing.T) {
+	tests := map[string]struct {
+		strategy Strategy
+		want     string
+	}{
+		"Choose with least connection": {&LeastConnectionStrategy{}, "c"},
+		"Choose with weighted":         {&WeightedStrategy{}, "b"},
+		"Choose with pack":             {&PackStrategy{}, "b"},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			got, err := test.strategy.Choose(loads)
+			if err != nil {
+				t.Fatalf("want %v but got error %v", test.want, err)
+			}
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRoundRobinChoose(t *testing.T) {
+	strategy := &RoundRobinStrategy{}
+	for _, want := range []string{"a", "b", "c", "a"} {
+		got, _ := strategy.Choose(loads)
+		if got != want {
+			t.Errorf("want %v but got %v", want, got)
+		}
+	}
+}
+
+func TestChooseWithNoHosts(t *testing.T) {
+	strategies := map[string]Strategy{
+		STRATEGY_LEAST_CONNECTION: &LeastConnectionStrategy{},
+		STRATEGY_ROUND_ROBIN:      &RoundRobinStrategy{},
+		STRATEGY_WEIGHTED:         &WeightedStrategy{},
+		STRATEGY_PACK:             &PackStrategy{},
+	}
+
+	for name, strategy := range strategies {
+		t.Run(name, func(t *testing.T) {
+			_, err := strategy.Choose([]HostLoad{})
+			if err == nil {
+				t.Errorf("want error but got nil")
+			}
+		})
+	}
+}
+
+func TestHasSpace(t *testing.T) {
+	tests := map[string]struct {
+		load HostLoad
+		want bool
+	}{
+		"hasSpace with unlimited capacity": {HostLoad{Occupancy: 100, Capacity: 0}, true},
+		"hasSpace with room to spare":      {HostLoad{Occupancy: 0, Capacity: 16}, true},
+		"hasSpace when full":               {HostLoad{Occupancy: 8, Capacity: 16}, false},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			got := test.load.hasSpace(16)
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
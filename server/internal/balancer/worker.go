@@ -2,13 +2,17 @@ package balancer
 
 import (
 	"bytes"
+	"cmp"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"server/internal/game"
 	"server/internal/room"
 	"server/internal/session"
 	"server/pb"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -17,51 +21,101 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+const HEARTBEAT_INTERVAL = 15 * time.Second
+
 type Worker struct {
-	host string
-	port string
+	host      string
+	port      string
+	startedAt int64 // unix milliseconds
+	capacity  int   // max number of clients, or 0 if unlimited
+	weight    int   // relative share of clients, or 0 to use capacity
 
-	lobby  *room.Lobby
-	secret []byte
+	lobby     *room.Lobby
+	secret    []byte
+	used      *session.ReplayGuard // tokens which opened a connection
+	internal  InternalConfig
+	masterUrl string // address of the master's internal api
 }
 
-func NewWorker(host string, port string, secret []byte) *Worker {
+func NewWorker(
+	host string,
+	port string,
+	secret []byte,
+	internal InternalConfig,
+	masterUrl string,
+	config game.Config,
+	idleTimeout time.Duration,
+	reconnectGrace time.Duration,
+	recordDir string,
+	capacity int,
+	weight int,
+) *Worker {
 	return &Worker{
-		host:   host,
-		port:   port,
-		lobby:  room.NewLobby(),
-		secret: secret,
+		host:      host,
+		port:      port,
+		startedAt: time.Now().UnixMilli(),
+		capacity:  capacity,
+		weight:    weight,
+		lobby:     room.NewLobby(config, idleTimeout, reconnectGrace, recordDir),
+		secret:    secret,
+		used:      session.NewReplayGuard(),
+		internal:  internal,
+		masterUrl: masterUrl,
 	}
 }
 
-func RegisterWorker(host string, port string) error {
+// Register registers the worker with the master. It is also called
+// periodically as a heartbeat, so that the master can tell when the worker
+// has restarted.
+func (w *Worker) Register() error {
 	body, err := proto.Marshal(&pb.RegisterRequest{
-		Host: host,
-		Port: port,
+		Host:         w.host,
+		Port:         w.port,
+		StartedAt:    w.startedAt,
+		Capacity:     uint32(w.capacity),
+		Weight:       uint32(w.weight),
+		InternalPort: w.internal.Port,
 	})
 	if err != nil {
 		return err
 	}
 
-	url := "http://localhost:5173/internal/register"
+	url := fmt.Sprintf("%s/internal/register", w.masterUrl)
 	request, err := http.NewRequest("PUT", url, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
 	request.Header.Add("Content-Type", "application/json")
+	err = signRequest(request, w.host+w.port, w.internal.Secret)
+	if err != nil {
+		return err
+	}
 
 	client := &http.Client{}
 	response, err := client.Do(request)
 	if err != nil {
 		return err
 	}
-	if response.StatusCode != http.StatusCreated {
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
 		return fmt.Errorf("unexpected status code %v", response.StatusCode)
 	}
 
 	return nil
 }
 
+// sendHeartbeats periodically registers the worker with the master again.
+func (w *Worker) sendHeartbeats() {
+	ticker := time.NewTicker(HEARTBEAT_INTERVAL)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		err := w.Register()
+		if err != nil {
+			log.Printf("failed to send heartbeat: %v", err)
+		}
+	}
+}
+
 func (w *Worker) Serve() {
 	corsHandler := cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:5173"},
@@ -76,8 +130,13 @@ func (w *Worker) Serve() {
 
 	r.Get("/api/room/snapshot", w.HandleSnapshot)
 	r.Get("/api/room/ws", w.HandleWS)
-	r.Put("/internal/create", w.HandleCreate)
-	r.Get("/internal/status", w.HandleStatus)
+	serveInternal(r, w.host, w.port, w.internal, func(r chi.Router) {
+		r.Put("/internal/create", w.HandleCreate)
+		r.Get("/internal/status", w.HandleStatus)
+	})
+
+	go w.lobby.CloseIdleRooms()
+	go w.sendHeartbeats()
 
 	log.Printf("game server is running on http://%s%s", w.host, w.port)
 	if err := http.ListenAndServe(w.port, r); err != nil {
@@ -87,14 +146,14 @@ func (w *Worker) Serve() {
 
 func (w *Worker) HandleSnapshot(rw http.ResponseWriter, r *http.Request) {
 	token := r.URL.Query().Get("token")
-	claims, err := session.ParseToken(token, w.secret)
+	claims, err := session.ParseToken(token, w.host+w.port, w.secret)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		http.Error(rw, err.Error(), http.StatusUnauthorized)
 		return
 	}
 
-	roomId := claims["roomId"].(string)
-	snapshot := w.lobby.GetSnapshot(roomId)
+	roomId := claims.RoomId
+	snapshot := w.lobby.GetSnapshot(roomId, claims.ClientId)
 	if snapshot == nil {
 		http.Error(rw, fmt.Sprintf("could not find room %s", roomId), http.StatusNotFound)
 		return
@@ -115,9 +174,9 @@ func (w *Worker) HandleSnapshot(rw http.ResponseWriter, r *http.Request) {
 
 func (w *Worker) HandleWS(rw http.ResponseWriter, r *http.Request) {
 	token := r.URL.Query().Get("token")
-	claims, err := session.ParseToken(token, w.secret)
+	claims, err := session.ParseToken(token, w.host+w.port, w.secret)
 	if err != nil {
-		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		http.Error(rw, err.Error(), http.StatusUnauthorized)
 		return
 	}
 
@@ -127,18 +186,43 @@ func (w *Worker) HandleWS(rw http.ResponseWriter, r *http.Request) {
 		},
 	}
 
+	roomId := claims.RoomId
+	room := w.lobby.GetRoom(roomId)
+	if room == nil {
+		http.Error(rw, fmt.Sprintf("could not find room %s", roomId), http.StatusNotFound)
+		return
+	}
+
+	// Resume tokens cannot take over a live session, or bring back a
+	// client whose Player has already been removed
+	if claims.IsResume && !room.IsDisconnected(claims.ClientId) {
+		http.Error(rw, fmt.Sprintf("client %s cannot resume", claims.ClientId), http.StatusConflict)
+		return
+	}
+
+	if !room.HasSpace(claims.ClientId, claims.IsSpectator) {
+		http.Error(rw, fmt.Sprintf("room %s is full", roomId), http.StatusConflict)
+		return
+	}
+
+	// Each token can only be used to open a single connection
+	err = w.used.Use(&claims.RegisteredClaims)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
 	conn, err := upgrader.Upgrade(rw, r, nil)
 	if err != nil {
 		http.Error(rw, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	room := w.lobby.GetRoom(claims["roomId"].(string))
-	room.InitClient(
-		claims["clientId"].(string),
-		claims["username"].(string),
-		conn,
-	)
+	err = room.InitClient(claims.ClientId, claims.Username, claims.IsSpectator, conn)
+	if err != nil {
+		log.Printf("failed to connect %s: %v", claims.ClientId, err)
+		conn.Close()
+	}
 }
 
 func (w *Worker) HandleCreate(rw http.ResponseWriter, r *http.Request) {
@@ -155,7 +239,33 @@ func (w *Worker) HandleCreate(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.lobby.CreateRoom(request.RoomId)
+	mode, err := game.NewGameMode(request.Mode, int(request.ScoreLimit))
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	boundary, err := game.NewBoundary(request.Boundary)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rules := game.Rules{
+		Mode:          mode,
+		RoundDuration: time.Duration(request.RoundDuration) * time.Second,
+		WorldSize:     float64(cmp.Or(request.WorldSize, game.DEFAULT_WORLD_SIZE)),
+		Boundary:      boundary,
+	}
+	err = w.lobby.CreateRoom(request.RoomId, rules, int(request.MaxPlayers))
+	if errors.Is(err, room.ErrRoomExists) {
+		http.Error(rw, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	rw.WriteHeader(http.StatusCreated)
 }
 
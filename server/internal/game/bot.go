@@ -0,0 +1,302 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"server/internal/game/constants"
+	"server/internal/game/entities"
+	"server/internal/game/geometry"
+	"server/pb"
+)
+
+const (
+	BOT_SIGHT_RANGE     = 2400.0 // max distance to notice other entities
+	BOT_AVOID_RANGE     = 600.0  // max distance ahead to dodge asteroids
+	BOT_AVOID_MARGIN    = 40.0   // extra clearance around asteroids
+	BOT_FIRE_RANGE      = 1200.0
+	BOT_AIM_TOLERANCE   = 0.15 // max angle in radians between heading and target
+	BOT_FIRE_INTERVAL   = constants.FPS / 2
+	BOT_RESPAWN_DELAY   = 3 * constants.FPS
+	BOT_CRUISE_THROTTLE = 0.8
+)
+
+// A bot is a Player which is controlled by the server instead of a client.
+// Bots are driven through the same inputs as clients. Their inputs are not
+// recorded, since a rebuilt game steers its bots again.
+type bot struct {
+	id        string
+	sequence  uint32 // sequence number of the latest input
+	lastFired uint32 // tick when the bot last fired
+	respawnAt uint32 // tick when the bot respawns, or 0 if alive
+}
+
+func newBot(id string) *bot {
+	return &bot{
+		id:        id,
+		sequence:  0,
+		lastFired: 0,
+		respawnAt: 0,
+	}
+}
+
+// steer computes the bot's next input given its player and the entities
+// around it. The bot chases the nearest enemy, or the nearest powerup if it is
+// closer, dodges asteroids in its path, and fires when aligned with an enemy.
+// Enemies are decided by isEnemy, so that bots leave their teammates alone.
+// Bots with nothing in sight, or outside the safe zone, return to the middle of
+// the world.
+func (b *bot) steer(
+	player *entities.Player,
+	nearby []entities.Entity,
+	isEnemy func(id string) bool,
+	isOutsideZone bool,
+	worldSize float64,
+	tick uint32,
+) *pb.Event_InputEventData {
+	position := player.GetPosition()
+	heading := headingOf(player)
+
+	var enemy entities.Entity
+	var target entities.Entity
+	enemyDistance := math.Inf(1)
+	targetDistance := math.Inf(1)
+	for _, entity := range nearby {
+		other := entity.GetPosition()
+		distance := other.Sub(&position).Length()
+		switch entity.GetEntityType() {
+		case pb.EntityType_ENTITY_TYPE_PLAYER:
+			if isEnemy(entity.GetId()) && distance < enemyDistance {
+				enemy = entity
+				enemyDistance = distance
+			}
+		case pb.EntityType_ENTITY_TYPE_POWERUP:
+			if distance < targetDistance {
+				target = entity
+				targetDistance = distance
+			}
+		}
+	}
+	if enemy != nil && enemyDistance <= targetDistance {
+		target = enemy
+	}
+	if isOutsideZone {
+		target = nil
+	}
+
+	// Head back towards the middle of the world if there is nothing to chase,
+	// since that is where other entities are and where the zone shrinks to.
+	destination := geometry.Vector{X: worldSize / 2, Y: worldSize / 2}
+	if target == enemy && enemy != nil {
+		destination = lead(position, enemy)
+	} else if target != nil {
+		destination = target.GetPosition()
+	}
+	direction := *destination.Sub(&position)
+	if direction.Length() > geometry.EPSILON {
+		direction = *direction.Unit()
+	}
+	direction = *direction.Add(avoidAsteroids(position, heading, nearby))
+	if direction.Length() > geometry.EPSILON {
+		direction = *direction.Unit()
+	}
+	direction = *direction.Multiply(BOT_CRUISE_THROTTLE)
+
+	isFiring := false
+	if enemy != nil && enemyDistance < BOT_FIRE_RANGE &&
+		tick-b.lastFired >= BOT_FIRE_INTERVAL {
+		enemyPosition := lead(position, enemy)
+		aim := enemyPosition.Sub(&position)
+		angle := math.Abs(math.Remainder(aim.Angle()-heading.Angle(), 2*math.Pi))
+		isFiring = angle < BOT_AIM_TOLERANCE
+	}
+	if isFiring {
+		b.lastFired = tick
+	}
+
+	b.sequence++
+	return &pb.Event_InputEventData{
+		Id:           b.id,
+		MouseX:       direction.X,
+		MouseY:       direction.Y,
+		MousePressed: isFiring,
+		Sequence:     b.sequence,
+		Tick:         tick,
+	}
+}
+
+// avoidAsteroids returns a steering adjustment away from asteroids whose
+// bounding boxes lie in the path ahead of position. Closer asteroids push
+// harder.
+func avoidAsteroids(
+	position geometry.Vector,
+	heading geometry.Vector,
+	nearby []entities.Entity,
+) *geometry.Vector {
+	adjustment := geometry.NewVector(0, 0)
+	for _, entity := range nearby {
+		if entity.GetEntityType() != pb.EntityType_ENTITY_TYPE_ASTEROID {
+			continue
+		}
+
+		center, radius := getBounds(entity.GetBoundingBox())
+		offset := center.Sub(&position)
+		ahead := offset.X*heading.X + offset.Y*heading.Y
+		if ahead < 0 || ahead > BOT_AVOID_RANGE+radius {
+			continue
+		}
+
+		// Distance from the center of the asteroid to the bot's path.
+		normal := heading.Normal()
+		side := offset.X*normal.X + offset.Y*normal.Y
+		clearance := radius + entities.PLAYER_RADIUS + BOT_AVOID_MARGIN
+		if math.Abs(side) > clearance {
+			continue
+		}
+
+		// Turn towards whichever side of the asteroid is nearer.
+		strength := 1 - ahead/(BOT_AVOID_RANGE+radius)
+		away := normal.Multiply(-math.Copysign(strength*2, side))
+		adjustment = adjustment.Add(away)
+	}
+	return adjustment
+}
+
+// lead returns where a projectile fired from position should aim to hit
+// target, assuming that target keeps its velocity.
+func lead(position geometry.Vector, target entities.Entity) geometry.Vector {
+	targetPosition := target.GetPosition()
+	targetVelocity := target.GetVelocity()
+	time := targetPosition.Sub(&position).Length() / entities.PROJECTILE_SPEED
+	return *targetPosition.Add(targetVelocity.Multiply(time))
+}
+
+// getBounds returns the center of the axis-aligned bounds of b, and the radius
+// of a circle which contains them.
+func getBounds(b *geometry.BoundingBox) (geometry.Vector, float64) {
+	minX, maxX := b.HorizontalBounds()
+	minY, maxY := b.VerticalBounds()
+	center := geometry.Vector{X: (minX + maxX) / 2, Y: (minY + maxY) / 2}
+	radius := math.Hypot(maxX-minX, maxY-minY) / 2
+	return center, radius
+}
+
+// headingOf returns the direction that player is moving in, or the direction
+// it is facing if it is not moving.
+func headingOf(player *entities.Player) geometry.Vector {
+	velocity := player.GetVelocity()
+	if velocity.Length() > geometry.EPSILON {
+		return *velocity.Unit()
+	}
+	rotation := player.GetEntityData().GetRotation()
+	return geometry.Vector{X: math.Cos(rotation), Y: math.Sin(rotation)}
+}
+
+// getNearbyEntities returns the entities within BOT_SIGHT_RANGE of position.
+// The collision index is used to narrow down candidates. In a wrapped world,
+// entities across the edges of the world are returned as shifted copies, so
+// that bots chase and dodge them across the edges.
+func (g *Game) getNearbyEntities(position geometry.Vector) []entities.Entity {
+	nearby := []entities.Entity{}
+	for _, offset := range g.getWrapOffsets(position, BOT_SIGHT_RANGE, BOT_SIGHT_RANGE) {
+		center := position.Add(&offset)
+		candidates := sortedIds(g.entities)
+		if g.index != nil {
+			candidates = g.index.QueryHorizontal(
+				center.X-BOT_SIGHT_RANGE,
+				center.X+BOT_SIGHT_RANGE,
+			)
+		}
+
+		for _, id := range candidates {
+			entity, found := g.entities[id]
+			if !found {
+				continue
+			}
+			if math.Abs(entity.GetPosition().Y-center.Y) > BOT_SIGHT_RANGE {
+				continue
+			}
+			if offset.X != 0 || offset.Y != 0 {
+				entity = &shiftedEntity{Entity: entity, offset: *offset.Multiply(-1)}
+			}
+			nearby = append(nearby, entity)
+		}
+	}
+	return nearby
+}
+
+// steerBots queues an input for every bot which is alive, and respawns bots
+// after BOT_RESPAWN_DELAY ticks.
+func (g *Game) steerBots() {
+	for _, id := range sortedIds(g.bots) {
+		b := g.bots[id]
+		player, isAlive := g.entities[id].(*entities.Player)
+		if !isAlive {
+			if b.respawnAt == 0 {
+				b.respawnAt = g.tick + BOT_RESPAWN_DELAY
+			} else if g.tick >= b.respawnAt {
+				// Try again later if there is no safe place to respawn.
+				b.respawnAt = 0
+				if g.spawnPlayer(id) != nil {
+					b.respawnAt = g.tick + BOT_RESPAWN_DELAY
+				} else {
+					g.recordBotRespawn(id)
+				}
+			}
+			continue
+		}
+
+		isEnemy := func(otherId string) bool {
+			return otherId != id && g.config.Mode.CanDamage(g.teams[id], g.teams[otherId])
+		}
+		nearby := g.getNearbyEntities(player.GetPosition())
+		input := b.steer(
+			player,
+			nearby,
+			isEnemy,
+			g.isOutsideZone(player.GetPosition()),
+			g.config.WorldSize,
+			g.tick,
+		)
+		g.inputs[id] = append(g.inputs[id], input)
+	}
+}
+
+// balanceBots adds or removes bots so that the game has at least BotCount
+// players. Bots are dropped as humans join, and added back as humans leave.
+func (g *Game) balanceBots() {
+	humans := len(g.usernames) - len(g.bots)
+	want := max(g.config.BotCount-humans, 0)
+
+	for len(g.bots) > want {
+		ids := sortedIds(g.bots)
+		id := ids[len(ids)-1]
+		g.removePlayer(id)
+		delete(g.bots, id)
+	}
+	for len(g.bots) < want {
+		id, err := g.spawner.NewId()
+		if err != nil {
+			return
+		}
+		g.bots[id] = newBot(id)
+		err = g.addPlayer(id, fmt.Sprintf("bot-%s", id))
+		if err != nil {
+			delete(g.bots, id)
+			return
+		}
+	}
+}
+
+// recordBotRespawn records that the bot with id respawned, so that replays
+// can show it. Rebuilt games respawn their bots by themselves.
+func (g *Game) recordBotRespawn(id string) {
+	g.record(&pb.Event{
+		Type: pb.EventType_EVENT_TYPE_RESPAWN,
+		Data: &pb.Event_RespawnEventData_{
+			RespawnEventData: &pb.Event_RespawnEventData{
+				Id:    id,
+				IsBot: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,294 @@
+package game
+
+import (
+	"fmt"
+	"math"
+	"server/internal/game/constants"
+	"server/internal/game/entities"
+	"server/internal/game/geometry"
+	"server/pb"
+	"slices"
+)
+
+const (
+	BOUNDARY_WRAP           = "wrap"
+	BOUNDARY_BOUNCE         = "bounce"
+	BOUNDARY_SHRINKING_ZONE = "zone"
+
+	ZONE_SHRINK_DURATION = 3 * 60 * constants.FPS // ticks for the zone to stop shrinking
+	ZONE_MIN_SCALE       = 0.1                    // final size of the zone relative to the world
+	ZONE_DAMAGE_INTERVAL = constants.FPS / 4      // ticks between damage outside the zone
+	ZONE_DAMAGE          = 5                      // damage per interval outside the zone
+)
+
+// A Boundary decides what happens to entities at the edges of the world. The
+// world is a square which spans from the origin to its size on both axes.
+type Boundary interface {
+	GetType() pb.Boundary
+
+	// Bound keeps entity within a world of size. It reports whether entity
+	// left the world and should be removed instead.
+	Bound(entity entities.Entity, size float64) bool
+
+	// GetZoneSize returns the size of the safe zone at the center of a world
+	// of size, after elapsed ticks of a round, or 0 if there is no zone.
+	GetZoneSize(size float64, elapsed uint32) float64
+}
+
+// NewBoundary returns the Boundary for boundary.
+func NewBoundary(boundary pb.Boundary) (Boundary, error) {
+	switch boundary {
+	case pb.Boundary_BOUNDARY_WRAP:
+		return &WrapBoundary{}, nil
+	case pb.Boundary_BOUNDARY_BOUNCE:
+		return &BounceBoundary{}, nil
+	case pb.Boundary_BOUNDARY_SHRINKING_ZONE:
+		return &ShrinkingZoneBoundary{}, nil
+	default:
+		return nil, fmt.Errorf("unknown boundary %v", boundary)
+	}
+}
+
+// ParseBoundary returns the type of the boundary with name.
+func ParseBoundary(name string) (pb.Boundary, error) {
+	switch name {
+	case BOUNDARY_WRAP:
+		return pb.Boundary_BOUNDARY_WRAP, nil
+	case BOUNDARY_BOUNCE:
+		return pb.Boundary_BOUNDARY_BOUNCE, nil
+	case BOUNDARY_SHRINKING_ZONE:
+		return pb.Boundary_BOUNDARY_SHRINKING_ZONE, nil
+	default:
+		return 0, fmt.Errorf("unknown boundary %s", name)
+	}
+}
+
+// WrapBoundary joins the opposite edges of the world, so that entities which
+// leave through one edge come back through the other.
+type WrapBoundary struct{}
+
+func (b *WrapBoundary) GetType() pb.Boundary {
+	return pb.Boundary_BOUNDARY_WRAP
+}
+
+func (b *WrapBoundary) Bound(entity entities.Entity, size float64) bool {
+	position := entity.GetPosition()
+	x := wrap(position.X, size)
+	y := wrap(position.Y, size)
+	if x != position.X || y != position.Y {
+		entity.Move(*geometry.NewVector(x, y), entity.GetVelocity())
+	}
+	return false
+}
+
+func (b *WrapBoundary) GetZoneSize(size float64, elapsed uint32) float64 {
+	return 0
+}
+
+// BounceBoundary surrounds the world with walls. Entities bounce off the walls,
+// except for projectiles which are stopped by them.
+type BounceBoundary struct{}
+
+func (b *BounceBoundary) GetType() pb.Boundary {
+	return pb.Boundary_BOUNDARY_BOUNCE
+}
+
+func (b *BounceBoundary) Bound(entity entities.Entity, size float64) bool {
+	return bounce(entity, size)
+}
+
+func (b *BounceBoundary) GetZoneSize(size float64, elapsed uint32) float64 {
+	return 0
+}
+
+// ShrinkingZoneBoundary surrounds the world with walls like BounceBoundary.
+// Each round, a safe zone shrinks from the size of the world towards its
+// center, and players which stay outside of the zone take damage.
+type ShrinkingZoneBoundary struct{}
+
+func (b *ShrinkingZoneBoundary) GetType() pb.Boundary {
+	return pb.Boundary_BOUNDARY_SHRINKING_ZONE
+}
+
+func (b *ShrinkingZoneBoundary) Bound(entity entities.Entity, size float64) bool {
+	return bounce(entity, size)
+}
+
+func (b *ShrinkingZoneBoundary) GetZoneSize(size float64, elapsed uint32) float64 {
+	progress := min(float64(elapsed)/ZONE_SHRINK_DURATION, 1)
+	return size * (1 - progress*(1-ZONE_MIN_SCALE))
+}
+
+// wrap returns coordinate wrapped into the range [0, size).
+func wrap(coordinate float64, size float64) float64 {
+	coordinate = math.Mod(coordinate, size)
+	if coordinate < 0 {
+		coordinate += size
+	}
+	return coordinate
+}
+
+// bounce moves entity back within a world of size if its bounding box crosses
+// an edge, and turns its velocity away from the edge. It reports whether
+// entity is a projectile which hit an edge.
+func bounce(entity entities.Entity, size float64) bool {
+	minX, maxX := entity.GetBoundingBox().HorizontalBounds()
+	minY, maxY := entity.GetBoundingBox().VerticalBounds()
+	if minX >= 0 && maxX <= size && minY >= 0 && maxY <= size {
+		return false
+	}
+	if entity.GetEntityType() == pb.EntityType_ENTITY_TYPE_PROJECTILE {
+		return true
+	}
+
+	position := entity.GetPosition()
+	velocity := entity.GetVelocity()
+	position.X, velocity.X = reflect(position.X, velocity.X, minX, maxX, size)
+	position.Y, velocity.Y = reflect(position.Y, velocity.Y, minY, maxY, size)
+	entity.Move(position, velocity)
+	return false
+}
+
+// reflect moves coordinate so that the bounds [low, high] around it are
+// within [0, size], and points speed away from the edge that was crossed.
+func reflect(
+	coordinate float64,
+	speed float64,
+	low float64,
+	high float64,
+	size float64,
+) (float64, float64) {
+	switch {
+	case low < 0:
+		return coordinate - low, math.Abs(speed)
+	case high > size:
+		return coordinate - (high - size), -math.Abs(speed)
+	default:
+		return coordinate, speed
+	}
+}
+
+// bound keeps entity within the world, and marks it for removal if it left
+// the world.
+func (g *Game) bound(entity entities.Entity) {
+	if g.config.Boundary.Bound(entity, g.config.WorldSize) {
+		g.remove(entity)
+	}
+}
+
+// isWrapped reports whether the edges of the world are joined.
+func (g *Game) isWrapped() bool {
+	return g.config.Boundary.GetType() == pb.Boundary_BOUNDARY_WRAP
+}
+
+// getWrapOffsets returns the offsets which move the area within halfWidth and
+// halfHeight of center across the edges of a wrapped world that it crosses,
+// including the zero offset. Entities near the area on the other side of the
+// world can be found by querying the area moved by each offset.
+func (g *Game) getWrapOffsets(
+	center geometry.Vector,
+	halfWidth float64,
+	halfHeight float64,
+) []geometry.Vector {
+	if !g.isWrapped() {
+		return []geometry.Vector{{X: 0, Y: 0}}
+	}
+
+	size := g.config.WorldSize
+	dxs := geometry.GetWrapOffsets(center.X-halfWidth, center.X+halfWidth, size)
+	dys := geometry.GetWrapOffsets(center.Y-halfHeight, center.Y+halfHeight, size)
+	offsets := []geometry.Vector{}
+	for _, dx := range dxs {
+		for _, dy := range dys {
+			offsets = append(offsets, geometry.Vector{X: dx, Y: dy})
+		}
+	}
+	return offsets
+}
+
+// getNearestCopy returns position, or its copy across the edges of a wrapped
+// world, whichever is nearest to from.
+func (g *Game) getNearestCopy(from geometry.Vector, position geometry.Vector) geometry.Vector {
+	if !g.isWrapped() {
+		return position
+	}
+
+	size := g.config.WorldSize
+	position.X += size * math.Round((from.X-position.X)/size)
+	position.Y += size * math.Round((from.Y-position.Y)/size)
+	return position
+}
+
+// A shiftedEntity is an entity as seen across the edges of a wrapped world.
+// Its position and bounding box are moved by offset, so that it can be
+// treated as if it were on the near side of the edge.
+type shiftedEntity struct {
+	entities.Entity
+	offset geometry.Vector
+}
+
+func (e *shiftedEntity) GetPosition() geometry.Vector {
+	position := e.Entity.GetPosition()
+	return *position.Add(&e.offset)
+}
+
+func (e *shiftedEntity) GetBoundingBox() *geometry.BoundingBox {
+	return e.Entity.GetBoundingBox().Shift(e.offset.X, e.offset.Y)
+}
+
+// findCollisions returns the IDs of entities which collided with boundingBox
+// at tick, in sorted order. Collisions across the edges of a wrapped world are
+// included.
+func (g *Game) findCollisions(tick uint32, boundingBox *geometry.BoundingBox) []string {
+	ids := g.history.FindCollisions(tick, boundingBox)
+	if !g.isWrapped() {
+		return ids
+	}
+
+	for _, c := range boundingBox.GetWrappedCopies(g.config.WorldSize) {
+		ids = append(ids, g.history.FindCollisions(tick, c)...)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// updateZone shrinks the safe zone, and damages players outside of it every
+// ZONE_DAMAGE_INTERVAL ticks.
+func (g *Game) updateZone() {
+	g.zoneSize = g.config.Boundary.GetZoneSize(
+		g.config.WorldSize,
+		g.tick-g.round.startTick,
+	)
+	if g.zoneSize == 0 {
+		return
+	}
+
+	for _, id := range sortedIds(g.usernames) {
+		player, found := g.entities[id].(*entities.Player)
+		if !found || !g.isOutsideZone(player.GetPosition()) {
+			delete(g.outside, id)
+			continue
+		}
+
+		g.outside[id]++
+		if g.outside[id]%ZONE_DAMAGE_INTERVAL != 0 {
+			continue
+		}
+		g.updated[id] = player
+		if player.Damage(ZONE_DAMAGE, pb.DamageCause_DAMAGE_CAUSE_ZONE, "") {
+			delete(g.outside, id)
+			g.remove(player)
+		}
+	}
+}
+
+// isOutsideZone reports whether position is outside of the safe zone. Every
+// position is inside if there is no zone.
+func (g *Game) isOutsideZone(position geometry.Vector) bool {
+	if g.zoneSize == 0 {
+		return false
+	}
+	center := g.config.WorldSize / 2
+	return math.Abs(position.X-center) > g.zoneSize/2 ||
+		math.Abs(position.Y-center) > g.zoneSize/2
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"server/internal/game/entities"
+	"server/internal/game/geometry"
+	"testing"
+	"time"
+)
+
+func TestBound(t *testing.T) {
+	square := geometry.NewRectangleHull(2, 2)
+	tests := map[string]struct {
+		boundary Boundary
+		position geometry.Vector
+		want     geometry.Vector
+	}{
+		"wrap inside the world":           {&WrapBoundary{}, geometry.Vector{X: 5, Y: 5}, geometry.Vector{X: 5, Y: 5}},
+		"wrap past the right edge":        {&WrapBoundary{}, geometry.Vector{X: 12, Y: 5}, geometry.Vector{X: 2, Y: 5}},
+		"wrap past the top edge":          {&WrapBoundary{}, geometry.Vector{X: 5, Y: -1}, geometry.Vector{X: 5, Y: 9}},
+		"bounce inside the world":         {&BounceBoundary{}, geometry.Vector{X: 5, Y: 5}, geometry.Vector{X: 5, Y: 5}},
+		"bounce off the right edge":       {&BounceBoundary{}, geometry.Vector{X: 12, Y: 5}, geometry.Vector{X: 9, Y: 5}},
+		"bounce off a corner":             {&BounceBoundary{}, geometry.Vector{X: -1, Y: 10}, geometry.Vector{X: 1, Y: 9}},
+		"shrinking zone has walls":        {&ShrinkingZoneBoundary{}, geometry.Vector{X: 0.5, Y: 5}, geometry.Vector{X: 1, Y: 5}},
+		"shrinking zone inside the world": {&ShrinkingZoneBoundary{}, geometry.Vector{X: 5, Y: 5}, geometry.Vector{X: 5, Y: 5}},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			entity := entities.NewMockEntity("1", test.position.X, test.position.Y, 0, square)
+			isRemoved := test.boundary.Bound(entity, 10)
+			got := entity.GetPosition()
+			if isRemoved || !got.IsEqual(&test.want) {
+				t.Errorf("want %v but got %v (removed: %v)", test.want, got, isRemoved)
+			}
+		})
+	}
+}
+
+func TestGetZoneSize(t *testing.T) {
+	tests := map[string]struct {
+		boundary Boundary
+		elapsed  uint32
+		want     float64
+	}{
+		"wrap has no zone":             {&WrapBoundary{}, 0, 0},
+		"bounce has no zone":           {&BounceBoundary{}, ZONE_SHRINK_DURATION, 0},
+		"zone starts at world size":    {&ShrinkingZoneBoundary{}, 0, 1000},
+		"zone shrinks over time":       {&ShrinkingZoneBoundary{}, ZONE_SHRINK_DURATION / 2, 550},
+		"zone stops at its final size": {&ShrinkingZoneBoundary{}, ZONE_SHRINK_DURATION * 2, 100},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			got := test.boundary.GetZoneSize(1000, test.elapsed)
+			if got < test.want-geometry.EPSILON || got > test.want+geometry.EPSILON {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestGetVisibleIds(t *testing.T) {
+	square := geometry.NewRectangleHull(100, 100)
+	tests := map[string]struct {
+		boundary Boundary
+		position geometry.Vector
+		want     bool
+	}{
+		"wrap near the view":          {&WrapBoundary{}, geometry.Vector{X: 2000, Y: 5000}, true},
+		"wrap far from the view":      {&WrapBoundary{}, geometry.Vector{X: 5000, Y: 5000}, false},
+		"wrap across the left edge":   {&WrapBoundary{}, geometry.Vector{X: 9500, Y: 5000}, true},
+		"wrap across a corner":        {&WrapBoundary{}, geometry.Vector{X: 9500, Y: 9500}, false},
+		"bounce across the left edge": {&BounceBoundary{}, geometry.Vector{X: 9500, Y: 5000}, false},
+		"wrap beyond the left edge":   {&WrapBoundary{}, geometry.Vector{X: 7700, Y: 5000}, false},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			clock := NewManualClock(time.UnixMilli(0))
+			config := NewConfig(DEFAULT_REWIND_WINDOW, 0)
+			config.Boundary = test.boundary
+			g := NewGame(config, 1, clock, nil)
+			clear(g.entities)
+			g.entities["mock"] = entities.NewMockEntity("mock", test.position.X, test.position.Y, 0, square)
+			g.resolveCollisions()
+
+			v := newView("client", geometry.Vector{X: 500, Y: 5000})
+			got := g.getVisibleIds(v)["mock"]
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestGetNearestCopy(t *testing.T) {
+	tests := map[string]struct {
+		boundary Boundary
+		position geometry.Vector
+		want     geometry.Vector
+	}{
+		"wrap on the near side":       {&WrapBoundary{}, geometry.Vector{X: 2000, Y: 500}, geometry.Vector{X: 2000, Y: 500}},
+		"wrap across the left edge":   {&WrapBoundary{}, geometry.Vector{X: 9500, Y: 500}, geometry.Vector{X: -500, Y: 500}},
+		"wrap across a corner":        {&WrapBoundary{}, geometry.Vector{X: 9500, Y: 9500}, geometry.Vector{X: -500, Y: -500}},
+		"bounce across the left edge": {&BounceBoundary{}, geometry.Vector{X: 9500, Y: 500}, geometry.Vector{X: 9500, Y: 500}},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			clock := NewManualClock(time.UnixMilli(0))
+			config := NewConfig(DEFAULT_REWIND_WINDOW, 0)
+			config.Boundary = test.boundary
+			g := NewGame(config, 1, clock, nil)
+
+			got := g.getNearestCopy(geometry.Vector{X: 500, Y: 500}, test.position)
+			if !got.IsEqual(&test.want) {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
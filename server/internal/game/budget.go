@@ -0,0 +1,170 @@
+package game
+
+import (
+	"cmp"
+	"math"
+	"server/internal/game/constants"
+	"server/internal/game/entities"
+	"server/internal/game/geometry"
+	"server/pb"
+	"slices"
+)
+
+const (
+	ASTEROIDS_PER_PLAYER    = 8
+	MIN_ASTEROID_BUDGET     = 16
+	MAX_ASTEROID_BUDGET     = 96
+	ASTEROID_SPAWN_INTERVAL = 6 * constants.FPS // ticks between spawns near the target
+	ASTEROID_CULL_DISTANCE  = 4000.0            // min distance from every player to cull
+
+	POWERUPS_PER_PLAYER    = 1
+	MIN_POWERUP_BUDGET     = 3
+	MAX_POWERUP_BUDGET     = 12
+	POWERUP_SPAWN_INTERVAL = 20 * constants.FPS
+
+	PROJECTILES_PER_PLAYER = 24 // max projectiles in flight for each player
+
+	MIN_SPAWN_INTERVAL = constants.FPS / 2 // ticks between spawns far below the target
+)
+
+// A budget is the target number of entities of a type, which scales with the
+// number of players. Spawns slow down as the count approaches the target, so
+// that the game stays near the target density.
+type budget struct {
+	perPlayer int
+	min       int
+	max       int
+	interval  uint32 // ticks between spawns near the target
+	lastSpawn uint32 // tick of the latest spawn
+}
+
+func newBudget(perPlayer int, min int, max int, interval uint32) *budget {
+	return &budget{
+		perPlayer: perPlayer,
+		min:       min,
+		max:       max,
+		interval:  interval,
+		lastSpawn: 0,
+	}
+}
+
+// getTarget returns the number of entities wanted for players.
+func (b *budget) getTarget(players int) int {
+	return min(max(players*b.perPlayer, b.min), b.max)
+}
+
+// shouldSpawn reports whether a new entity should be spawned at tick, given
+// count existing entities and players. The interval between spawns shrinks
+// in proportion to how far count is below the target.
+func (b *budget) shouldSpawn(count int, players int, tick uint32) bool {
+	target := b.getTarget(players)
+	if count >= target {
+		return false
+	}
+
+	interval := max(b.interval*uint32(count)/uint32(target), MIN_SPAWN_INTERVAL)
+	if tick-b.lastSpawn < interval {
+		return false
+	}
+	b.lastSpawn = tick
+	return true
+}
+
+// spawnEntities spawns asteroids and powerups as their budgets allow, and
+// culls far away asteroids while there are more than the budget.
+func (g *Game) spawnEntities() {
+	players := len(g.usernames)
+	asteroids := g.getIdsOfType(pb.EntityType_ENTITY_TYPE_ASTEROID)
+	powerups := g.getIdsOfType(pb.EntityType_ENTITY_TYPE_POWERUP)
+
+	if g.asteroids.shouldSpawn(len(asteroids), players, g.tick) {
+		if asteroid, err := g.spawner.SpawnAsteroid(g.isSafeSpawn); err == nil {
+			g.add(asteroid)
+		}
+	}
+	if g.powerups.shouldSpawn(len(powerups), players, g.tick) {
+		if powerup, err := g.spawner.SpawnPowerup(g.isSafeSpawn); err == nil {
+			g.add(powerup)
+		}
+	}
+
+	excess := len(asteroids) - g.asteroids.getTarget(players)
+	if excess > 0 {
+		g.cullAsteroids(asteroids, excess)
+	}
+}
+
+// cullAsteroids removes up to count of the asteroids with ids which are at
+// least ASTEROID_CULL_DISTANCE from every player, farthest first.
+func (g *Game) cullAsteroids(ids []string, count int) {
+	distances := make(map[string]float64)
+	far := []string{}
+	for _, id := range ids {
+		distance := g.getDistanceToPlayers(g.entities[id].GetPosition())
+		if distance >= ASTEROID_CULL_DISTANCE {
+			distances[id] = distance
+			far = append(far, id)
+		}
+	}
+
+	// Ties are broken by ID, since ids are sorted and the sort is stable.
+	slices.SortStableFunc(far, func(a, b string) int {
+		return cmp.Compare(distances[b], distances[a])
+	})
+	for _, id := range far[:min(count, len(far))] {
+		g.remove(g.entities[id])
+	}
+}
+
+// canFire reports whether the player with id has fewer projectiles in flight
+// than PROJECTILES_PER_PLAYER.
+func (g *Game) canFire(id string) bool {
+	count := 0
+	for _, entity := range g.entities {
+		projectile, ok := entity.(*entities.Projectile)
+		if ok && projectile.GetOwnerId() == id {
+			count++
+		}
+	}
+	return count < PROJECTILES_PER_PLAYER
+}
+
+// getIdsOfType returns the sorted IDs of entities of entityType which have not
+// been removed.
+func (g *Game) getIdsOfType(entityType pb.EntityType) []string {
+	ids := []string{}
+	for _, id := range sortedIds(g.entities) {
+		if g.entities[id].GetEntityType() == entityType &&
+			!slices.Contains(g.removed, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// getDistanceToPlayers returns the distance from position to the nearest
+// player, or infinity if there are no players. Distances in a wrapped world
+// are measured across its edges.
+func (g *Game) getDistanceToPlayers(position geometry.Vector) float64 {
+	nearest := math.Inf(1)
+	for _, id := range sortedIds(g.usernames) {
+		player, found := g.entities[id]
+		if !found {
+			continue
+		}
+		nearest = min(nearest, g.getDistance(position, player.GetPosition()))
+	}
+	return nearest
+}
+
+// getDistance returns the distance between a and b. Distances in a wrapped
+// world are measured across its edges if that is shorter.
+func (g *Game) getDistance(a geometry.Vector, b geometry.Vector) float64 {
+	dx := math.Abs(a.X - b.X)
+	dy := math.Abs(a.Y - b.Y)
+	if g.isWrapped() {
+		dx = min(dx, g.config.WorldSize-dx)
+		dy = min(dy, g.config.WorldSize-dy)
+	}
+	return math.Hypot(dx, dy)
+}
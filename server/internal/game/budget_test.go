@@ -0,0 +1,31 @@
+package game
+
+import "testing"
+
+func TestShouldSpawn(t *testing.T) {
+	tests := map[string]struct {
+		count   int
+		players int
+		tick    uint32
+		want    bool
+	}{
+		"spawn when empty":                     {0, 0, MIN_SPAWN_INTERVAL, true},
+		"wait between spawns when empty":       {0, 0, MIN_SPAWN_INTERVAL - 1, false},
+		"spawn slower near the target":         {8, 2, 79, false},
+		"spawn near the target after interval": {8, 2, 80, true},
+		"no spawn at the target":               {10, 0, 1000, false},
+		"target scales with players":           {10, 4, 1000, true},
+		"target is capped":                     {40, 100, 1000, false},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			// Targets 5 entities per player, between 10 and 40.
+			b := newBudget(5, 10, 40, 100)
+			got := b.shouldSpawn(test.count, test.players, test.tick)
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package game
+
+import (
+	"sync"
+	"time"
+)
+
+// A Clock tells the time for a game. Games only read the time through their
+// Clock, so that simulations can be run against a fixed time.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (c systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock is a Clock which tells the wall-clock time.
+var SystemClock Clock = systemClock{}
+
+// A ManualClock is a Clock which only moves when it is advanced.
+type ManualClock struct {
+	now time.Time
+	mu  sync.Mutex
+}
+
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{
+		now: now,
+		mu:  sync.Mutex{},
+	}
+}
+
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
@@ -1,6 +1,7 @@
 package collision
 
 import (
+	"maps"
 	"math"
 	"server/internal/game/entities"
 	"server/internal/game/geometry"
@@ -9,9 +10,50 @@ import (
 
 // An Edge represents a horizontal boundary.
 type Edge struct {
-	id     *string // id of the entity it belongs to
-	x      float64 // x-coordinate
-	isLeft bool    // whether the edge is a left or right edge
+	id          *string // id of the entity it belongs to
+	boundingBox *geometry.BoundingBox
+	x           float64 // x-coordinate
+	isLeft      bool    // whether the edge is a left or right edge
+}
+
+// An Index stores the horizontal boundaries of entities, ordered by
+// x-coordinate. It is computed while resolving collisions, and can be reused
+// for spatial queries until the entities move again.
+type Index struct {
+	edges      []Edge
+	rightEdges map[string]float64 // right x-coordinate of each entity
+}
+
+func NewIndex(entities *map[string]entities.Entity) *Index {
+	edges := getSortedEdges(entities)
+	rightEdges := make(map[string]float64, len(edges)/2)
+	for _, edge := range edges {
+		if !edge.isLeft {
+			rightEdges[*edge.id] = edge.x
+		}
+	}
+	return &Index{edges: edges, rightEdges: rightEdges}
+}
+
+// Contains reports whether the entity with id was indexed.
+func (idx *Index) Contains(id string) bool {
+	_, found := idx.rightEdges[id]
+	return found
+}
+
+// QueryHorizontal returns the IDs of indexed entities whose horizontal bounds
+// overlap with the range [minX, maxX].
+func (idx *Index) QueryHorizontal(minX float64, maxX float64) []string {
+	ids := []string{}
+	for _, edge := range idx.edges {
+		if edge.x > maxX {
+			break
+		}
+		if edge.isLeft && idx.rightEdges[*edge.id] >= minX {
+			ids = append(ids, *edge.id)
+		}
+	}
+	return ids
 }
 
 // A CollisionHandler is a callback to handle a collision between entities with
@@ -21,31 +63,74 @@ type CollisionHandler func(id1 *string, id2 *string)
 // ResolveCollisionsLineSweep resolves collisions in entities in O(n log(n))
 // time by using the line sweep algorithm. It maintains a window of entities
 // with overlapping x-coordinates and only checks collisions within the window.
+//
+// Collisions are handled in a deterministic order, so that games with the same
+// state resolve collisions in the same way. The sorted edges are returned as
+// an Index so that they can be reused.
 func ResolveCollisionsLineSweep(
 	entities *map[string]entities.Entity,
 	handleCollision CollisionHandler,
-) {
-	edges := getSortedEdges(entities)
+) *Index {
+	index := NewIndex(entities)
+	sweep(index.edges, handleCollision)
+	return index
+}
 
-	window := make(map[*string]bool)
-	for _, edge := range edges {
-		if edge.isLeft {
-			e1 := (*entities)[*edge.id]
+// ResolveCollisionsWrapped resolves collisions in entities like
+// ResolveCollisionsLineSweep, but in a square world of worldSize whose
+// opposite edges are joined. Entities which cross an edge also collide with
+// entities on the other side, and each colliding pair is handled once.
+//
+// The returned Index only contains the entities' actual bounds.
+func ResolveCollisionsWrapped(
+	entities *map[string]entities.Entity,
+	worldSize float64,
+	handleCollision CollisionHandler,
+) *Index {
+	index := NewIndex(entities)
 
-			for otherId := range window {
-				e2 := (*entities)[*otherId]
+	edges := slices.Clone(index.edges)
+	for _, id := range slices.Sorted(maps.Keys(*entities)) {
+		boundingBox := (*entities)[id].GetBoundingBox()
+		for _, c := range boundingBox.GetWrappedCopies(worldSize) {
+			edges = append(edges, newEdges(&id, c)...)
+		}
+	}
+	sortEdges(edges)
 
-				b1 := e1.GetBoundingBox()
-				b2 := e2.GetBoundingBox()
-				if !b1.DidCollide(b2) {
+	handled := make(map[[2]string]bool)
+	sweep(edges, func(id1 *string, id2 *string) {
+		pair := [2]string{min(*id1, *id2), max(*id1, *id2)}
+		if handled[pair] {
+			return
+		}
+		handled[pair] = true
+		handleCollision(id1, id2)
+	})
+	return index
+}
+
+// sweep checks for collisions between the bounding boxes of edges, which are
+// ordered by x-coordinate. Bounding boxes of the same entity never collide.
+func sweep(edges []Edge, handleCollision CollisionHandler) {
+	window := []Edge{} // ordered by left edge
+	for _, edge := range edges {
+		if edge.isLeft {
+			for _, other := range window {
+				if *other.id == *edge.id {
+					continue
+				}
+				if !edge.boundingBox.DidCollide(other.boundingBox) {
 					continue
 				}
 
-				handleCollision(edge.id, otherId)
+				handleCollision(edge.id, other.id)
 			}
-			window[edge.id] = true
+			window = append(window, edge)
 		} else {
-			delete(window, edge.id)
+			window = slices.DeleteFunc(window, func(other Edge) bool {
+				return other.boundingBox == edge.boundingBox
+			})
 		}
 	}
 }
@@ -78,26 +163,31 @@ func resolveCollisionsNaive(
 }
 
 // getSortedEdges returns all left and right edges in entities, ordered by
-// x-coordinate. Left edges are ordered first in case of ties.
+// x-coordinate. Left edges are ordered first in case of ties. Entities are
+// visited in ID order so that ties are always broken the same way.
 func getSortedEdges(entities *map[string]entities.Entity) []Edge {
-	edges := make([]Edge, len(*entities)*2)
-
-	i := 0
-	for id, entity := range *entities {
-		minX, maxX := entity.GetBoundingBox().HorizontalBounds()
-		edges[i] = Edge{
-			id:     &id,
-			x:      minX,
-			isLeft: true,
-		}
-		edges[i+1] = Edge{
-			id:     &id,
-			x:      maxX,
-			isLeft: false,
-		}
-		i += 2
+	edges := make([]Edge, 0, len(*entities)*2)
+	for _, id := range slices.Sorted(maps.Keys(*entities)) {
+		edges = append(edges, newEdges(&id, (*entities)[id].GetBoundingBox())...)
+	}
+
+	sortEdges(edges)
+	return edges
+}
+
+// newEdges returns the left and right edges of boundingBox, which belongs to
+// the entity with id.
+func newEdges(id *string, boundingBox *geometry.BoundingBox) []Edge {
+	minX, maxX := boundingBox.HorizontalBounds()
+	return []Edge{
+		{id: id, boundingBox: boundingBox, x: minX, isLeft: true},
+		{id: id, boundingBox: boundingBox, x: maxX, isLeft: false},
 	}
+}
 
+// sortEdges orders edges by x-coordinate, with left edges first in case of
+// ties.
+func sortEdges(edges []Edge) {
 	slices.SortFunc(edges, func(a Edge, b Edge) int {
 		// If edges are effectively overlapping, order any left edges first
 		// because left edges should only be ejected from the line sweep window
@@ -116,5 +206,4 @@ func getSortedEdges(entities *map[string]entities.Entity) []Edge {
 			return 1
 		}
 	})
-	return edges
 }
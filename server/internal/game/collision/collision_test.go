@@ -3,17 +3,19 @@ package collision
 import (
 	"fmt"
 	"math"
-	"math/rand/v2"
+	"math/rand"
 	"server/internal/game/entities"
 	"server/internal/game/geometry"
 	"slices"
 	"testing"
 )
 
+var random = rand.New(rand.NewSource(0))
+
 func randomEntity(id string) *entities.MockEntity {
-	position := *geometry.NewRandomVector(-100, 100, -100, 100)
-	rotation := rand.Float64() * math.Pi * 2
-	points := geometry.NewRandomConvexHull(8, 16, 8, 32)
+	position := *geometry.NewRandomVector(random, -100, 100, -100, 100)
+	rotation := random.Float64() * math.Pi * 2
+	points := geometry.NewRandomConvexHull(random, 8, 16, 8, 32)
 	return entities.NewMockEntity(id, position.X, position.Y, rotation, points)
 }
 
@@ -121,6 +123,68 @@ func TestResolveCollisionsLineSweep(t *testing.T) {
 	}
 }
 
+func TestResolveCollisionsWrapped(t *testing.T) {
+	left := entities.NewMockEntity("left", 0.5, 5, 0, square)
+	right := entities.NewMockEntity("right", 9.8, 5, 0, square)
+	top := entities.NewMockEntity("top", 0.5, 0.5, 0, square)
+	bottom := entities.NewMockEntity("bottom", 9.8, 9.8, 0, square)
+	middle := entities.NewMockEntity("middle", 5, 5, 0, square)
+
+	tests := map[string]struct {
+		entities map[string]entities.Entity
+		want     map[string][]string
+	}{
+		"ResolveCollisionsWrapped across an edge": {
+			map[string]entities.Entity{"left": left, "right": right},
+			map[string][]string{"left": {"right"}, "right": {"left"}},
+		},
+		"ResolveCollisionsWrapped across a corner": {
+			map[string]entities.Entity{"top": top, "bottom": bottom},
+			map[string][]string{"top": {"bottom"}, "bottom": {"top"}},
+		},
+		"ResolveCollisionsWrapped within the world": {
+			map[string]entities.Entity{"left": left, "top": top},
+			map[string][]string{"left": {}, "top": {}},
+		},
+		"ResolveCollisionsWrapped with one collision per pair": {
+			map[string]entities.Entity{
+				"top":    top,
+				"bottom": bottom,
+				"left":   left,
+				"right":  right,
+				"middle": middle,
+			},
+			map[string][]string{
+				"top":    {"bottom"},
+				"bottom": {"top"},
+				"left":   {"right"},
+				"right":  {"left"},
+				"middle": {},
+			},
+		},
+	}
+
+	for desc, test := range tests {
+		got := make(map[string][]string)
+		for id := range test.want {
+			got[id] = []string{}
+		}
+		handleCollision := func(id1 *string, id2 *string) {
+			got[*id1] = append(got[*id1], *id2)
+			got[*id2] = append(got[*id2], *id1)
+		}
+
+		t.Run(desc, func(t *testing.T) {
+			ResolveCollisionsWrapped(&test.entities, 10, handleCollision)
+			for id := range test.want {
+				if !slices.Equal(got[id], test.want[id]) {
+					t.Errorf("want %v but got %v", test.want[id], got[id])
+				}
+			}
+		})
+	}
+}
+
 func TestGetSortedEdges(t *testing.T) {
 	tests := map[string]struct {
 		entities map[string]entities.Entity
@@ -195,6 +259,41 @@ func TestGetSortedEdges(t *testing.T) {
 	}
 }
 
+func TestIndexQueryHorizontal(t *testing.T) {
+	index := NewIndex(&map[string]entities.Entity{
+		"1": e1,
+		"2": e2,
+		"5": e5,
+		"6": e6,
+	})
+
+	tests := map[string]struct {
+		minX float64
+		maxX float64
+		want []string
+	}{
+		"QueryHorizontal":                      {-0.5, 0.5, []string{"1", "2", "5"}},
+		"QueryHorizontal with touching bounds": {2, 3, []string{"2", "6"}},
+		"QueryHorizontal with spanning entity": {1.5, 1.6, []string{"2", "6"}},
+		"QueryHorizontal with empty result":    {4, 10, []string{}},
+	}
+
+	for desc, test := range tests {
+		title := fmt.Sprintf("%s: [%f, %f]", desc, test.minX, test.maxX)
+		t.Run(title, func(t *testing.T) {
+			got := index.QueryHorizontal(test.minX, test.maxX)
+			if len(got) != len(test.want) {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+			for _, id := range test.want {
+				if !slices.Contains(got, id) {
+					t.Errorf("want %v in %v", id, got)
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkResolveCollisionsLineSweep(b *testing.B) {
 	for b.Loop() {
 		ResolveCollisionsLineSweep(&benchmarkEntities, mockCollisionHandler)
@@ -0,0 +1,71 @@
+package collision
+
+import (
+	"server/internal/game/entities"
+	"server/internal/game/geometry"
+	"slices"
+)
+
+// A History is a ring buffer of the bounding boxes of entities over the most
+// recent ticks. It allows collisions to be checked against the game state as
+// it was at a past tick (e.g. as seen by a client with high latency).
+type History struct {
+	frames []frame
+}
+
+// A frame is the recorded state of the game at a single tick.
+type frame struct {
+	tick          uint32
+	isRecorded    bool
+	boundingBoxes map[string]*geometry.BoundingBox
+}
+
+// NewHistory creates a History which keeps the last size ticks.
+func NewHistory(size int) *History {
+	return &History{
+		frames: make([]frame, max(size, 1)),
+	}
+}
+
+// Record stores a copy of the bounding boxes of entities at tick, overwriting
+// the oldest recorded tick.
+func (h *History) Record(tick uint32, entities *map[string]entities.Entity) {
+	boundingBoxes := make(map[string]*geometry.BoundingBox, len(*entities))
+	for id, entity := range *entities {
+		boundingBoxes[id] = entity.GetBoundingBox().Copy()
+	}
+
+	h.frames[int(tick%uint32(len(h.frames)))] = frame{
+		tick:          tick,
+		isRecorded:    true,
+		boundingBoxes: boundingBoxes,
+	}
+}
+
+// Rewind returns the bounding boxes that were recorded at tick. It reports
+// false if tick was never recorded or has already been overwritten.
+func (h *History) Rewind(tick uint32) (map[string]*geometry.BoundingBox, bool) {
+	f := h.frames[int(tick%uint32(len(h.frames)))]
+	if !f.isRecorded || f.tick != tick {
+		return nil, false
+	}
+	return f.boundingBoxes, true
+}
+
+// FindCollisions returns the IDs of entities which collided with b at tick,
+// in sorted order. No IDs are returned if tick is not recorded.
+func (h *History) FindCollisions(tick uint32, b *geometry.BoundingBox) []string {
+	boundingBoxes, found := h.Rewind(tick)
+	if !found {
+		return nil
+	}
+
+	ids := []string{}
+	for id, other := range boundingBoxes {
+		if b.DidCollide(other) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
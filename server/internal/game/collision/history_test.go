@@ -0,0 +1,83 @@
+package collision
+
+import (
+	"fmt"
+	"server/internal/game/entities"
+	"server/internal/game/geometry"
+	"slices"
+	"testing"
+)
+
+// newTestHistory records e1 and e2 moving to the right by 4 units per tick,
+// over ticks 0 to 4.
+func newTestHistory(size int) *History {
+	history := NewHistory(size)
+	for tick := range uint32(5) {
+		x := float64(tick) * 4
+		history.Record(tick, &map[string]entities.Entity{
+			"1": entities.NewMockEntity("1", x, 0, 0, square),
+			"2": entities.NewMockEntity("2", x, 10, 0, square),
+		})
+	}
+	return history
+}
+
+func TestHistoryRewind(t *testing.T) {
+	history := newTestHistory(3)
+
+	tests := map[string]struct {
+		tick      uint32
+		wantFound bool
+		wantX     float64
+	}{
+		"Rewind to latest tick":          {4, true, 16},
+		"Rewind to oldest kept tick":     {2, true, 8},
+		"Rewind to overwritten tick":     {1, false, 0},
+		"Rewind to tick not yet reached": {7, false, 0},
+	}
+
+	for desc, test := range tests {
+		title := fmt.Sprintf("%s: %d", desc, test.tick)
+		t.Run(title, func(t *testing.T) {
+			boundingBoxes, found := history.Rewind(test.tick)
+			if found != test.wantFound {
+				t.Fatalf("want found %v but got %v", test.wantFound, found)
+			}
+			if !found {
+				return
+			}
+
+			minX, _ := boundingBoxes["1"].HorizontalBounds()
+			if minX+1 != test.wantX {
+				t.Errorf("want entity at x = %f but got %f", test.wantX, minX+1)
+			}
+		})
+	}
+}
+
+func TestHistoryFindCollisions(t *testing.T) {
+	history := newTestHistory(3)
+
+	position := geometry.NewVector(8, 0)
+	rotation := 0.0
+	b := geometry.NewBoundingBox(position, &rotation, &square)
+
+	tests := map[string]struct {
+		tick uint32
+		want []string
+	}{
+		"FindCollisions at tick where entity was hit": {2, []string{"1"}},
+		"FindCollisions at tick where entity moved":   {4, []string{}},
+		"FindCollisions at overwritten tick":          {0, []string{}},
+	}
+
+	for desc, test := range tests {
+		title := fmt.Sprintf("%s: %d", desc, test.tick)
+		t.Run(title, func(t *testing.T) {
+			got := history.FindCollisions(test.tick, b)
+			if !slices.Equal(got, test.want) {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"server/pb"
+)
+
+// A Command is an event sent by a client. ClientId is the ID of the
+// authenticated connection that the event was received from.
+type Command struct {
+	ClientId string
+	Event    *pb.Event
+}
+
+// clientEventTypes are the types of events which clients are allowed to send.
+// All other events are generated by the server.
+var clientEventTypes = map[pb.EventType]bool{
+	pb.EventType_EVENT_TYPE_RESPAWN:  true,
+	pb.EventType_EVENT_TYPE_INPUT:    true,
+	pb.EventType_EVENT_TYPE_SPECTATE: true,
+}
+
+// IsClientEventType reports whether clients are allowed to send events of
+// eventType.
+func IsClientEventType(eventType pb.EventType) bool {
+	return clientEventTypes[eventType]
+}
+
+// GetSenderId returns the client ID that event claims to be sent by, or an
+// empty string if event does not contain one.
+func GetSenderId(event *pb.Event) string {
+	switch event.GetType() {
+	case pb.EventType_EVENT_TYPE_RESPAWN:
+		return event.GetRespawnEventData().GetId()
+
+	case pb.EventType_EVENT_TYPE_INPUT:
+		return event.GetInputEventData().GetId()
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"server/internal/game/constants"
+	"server/internal/game/entities"
+	"server/pb"
+	"time"
+)
+
+const (
+	DEFAULT_REWIND_WINDOW = 200 * time.Millisecond
+	DEFAULT_WORLD_SIZE    = entities.DEFAULT_WORLD_SIZE
+)
+
+// A Config stores the settings of a game.
+type Config struct {
+	RewindWindow int // max number of ticks to rewind for lag compensation
+	BotCount     int // min number of players, which bots fill up to
+
+	// Rules.
+	Mode          GameMode
+	RoundDuration int     // max number of ticks in a round, or 0 if untimed
+	WorldSize     float64 // width and height of the world
+	Boundary      Boundary
+}
+
+// Rules are the settings of a game which can be chosen for each room.
+type Rules struct {
+	Mode          GameMode
+	RoundDuration time.Duration // or 0 if untimed
+	WorldSize     float64       // width and height of the world
+	Boundary      Boundary
+}
+
+// NewConfig creates a Config for an endless free-for-all in a wrapped world of
+// the default size.
+func NewConfig(rewindWindow time.Duration, botCount int) Config {
+	return Config{
+		RewindWindow:  int(rewindWindow / constants.FRAME_DURATION),
+		BotCount:      botCount,
+		Mode:          &FreeForAllMode{},
+		RoundDuration: 0,
+		WorldSize:     DEFAULT_WORLD_SIZE,
+		Boundary:      &WrapBoundary{},
+	}
+}
+
+// WithRules returns a copy of c which follows rules.
+func (c Config) WithRules(rules Rules) Config {
+	c.Mode = rules.Mode
+	c.RoundDuration = int(rules.RoundDuration / constants.FRAME_DURATION)
+	c.WorldSize = rules.WorldSize
+	c.Boundary = rules.Boundary
+	return c
+}
+
+// ToPb serializes c, so that a recorded game can be rebuilt with the same
+// settings.
+func (c Config) ToPb() *pb.MatchConfig {
+	return &pb.MatchConfig{
+		Mode:          c.Mode.GetType(),
+		ScoreLimit:    c.Mode.GetScoreLimit(),
+		BotCount:      uint32(c.BotCount),
+		RewindWindow:  uint32(c.RewindWindow),
+		RoundDuration: uint32(c.RoundDuration),
+		WorldSize:     c.WorldSize,
+		Boundary:      c.Boundary.GetType(),
+	}
+}
+
+// NewConfigFromPb creates the Config which was serialized as config.
+func NewConfigFromPb(config *pb.MatchConfig) (Config, error) {
+	mode, err := NewGameMode(config.GetMode(), int(config.GetScoreLimit()))
+	if err != nil {
+		return Config{}, err
+	}
+
+	boundary, err := NewBoundary(config.GetBoundary())
+	if err != nil {
+		return Config{}, err
+	}
+
+	return Config{
+		RewindWindow:  int(config.GetRewindWindow()),
+		BotCount:      int(config.GetBotCount()),
+		Mode:          mode,
+		RoundDuration: int(config.GetRoundDuration()),
+		WorldSize:     config.GetWorldSize(),
+		Boundary:      boundary,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"server/pb"
+	"slices"
+)
+
+// An entityState is the subset of an entity's EntityData that can change
+// between ticks. It is kept for each entity that has been sent to clients, so
+// that only changed fields need to be sent on the next tick.
+type entityState struct {
+	positionX      float64
+	positionY      float64
+	velocityX      float64
+	velocityY      float64
+	rotation       float64
+	score          uint32
+	flags          uint32
+	abilities      []abilityState
+	timers         []*pb.AbilityTimer // sent when abilities change
+	health         uint32
+	isInvulnerable bool
+	heat           uint32
+	isOverheated   bool
+	fireInterval   uint32
+}
+
+// An abilityState is an active ability of a player. The tick when the ability
+// expires is kept instead of the remaining ticks, so that timers counting down
+// are not sent as changes.
+type abilityState struct {
+	ability uint32
+	end     uint32
+	stacks  uint32
+}
+
+// newEntityState returns the state of data at tick.
+func newEntityState(data *pb.EntityData, tick uint32) entityState {
+	state := entityState{
+		positionX: data.GetPosition().GetX(),
+		positionY: data.GetPosition().GetY(),
+		velocityX: data.GetVelocity().GetX(),
+		velocityY: data.GetVelocity().GetY(),
+		rotation:  data.GetRotation(),
+	}
+	if playerData := data.GetPlayerData(); playerData != nil {
+		state.score = playerData.GetScore()
+		state.flags = playerData.GetFlags()
+		state.timers = playerData.GetAbilities()
+		state.health = playerData.GetHealth()
+		state.isInvulnerable = playerData.GetIsInvulnerable()
+		state.heat = playerData.GetHeat()
+		state.isOverheated = playerData.GetIsOverheated()
+		state.fireInterval = playerData.GetFireInterval()
+		for _, timer := range playerData.GetAbilities() {
+			state.abilities = append(state.abilities, abilityState{
+				ability: timer.GetAbility(),
+				end:     tick + timer.GetRemaining(),
+				stacks:  timer.GetStacks(),
+			})
+		}
+	}
+	return state
+}
+
+// diff returns an EntityDelta containing only the fields that differ between
+// s and next, or nil if nothing has changed.
+func (s entityState) diff(id string, next entityState) *pb.EntityDelta {
+	delta := &pb.EntityDelta{Id: id}
+	changed := false
+
+	if s.positionX != next.positionX || s.positionY != next.positionY {
+		delta.Position = &pb.Vector{X: next.positionX, Y: next.positionY}
+		changed = true
+	}
+	if s.velocityX != next.velocityX || s.velocityY != next.velocityY {
+		delta.Velocity = &pb.Vector{X: next.velocityX, Y: next.velocityY}
+		changed = true
+	}
+	if s.rotation != next.rotation {
+		delta.Rotation = &next.rotation
+		changed = true
+	}
+	if s.score != next.score {
+		delta.Score = &next.score
+		changed = true
+	}
+	if s.flags != next.flags {
+		delta.Flags = &next.flags
+		changed = true
+	}
+	if s.health != next.health {
+		delta.Health = &next.health
+		changed = true
+	}
+	if s.isInvulnerable != next.isInvulnerable {
+		delta.IsInvulnerable = &next.isInvulnerable
+		changed = true
+	}
+	if s.heat != next.heat {
+		delta.Heat = &next.heat
+		changed = true
+	}
+	if s.isOverheated != next.isOverheated {
+		delta.IsOverheated = &next.isOverheated
+		changed = true
+	}
+	if s.fireInterval != next.fireInterval {
+		delta.FireInterval = &next.fireInterval
+		changed = true
+	}
+	if !slices.Equal(s.abilities, next.abilities) {
+		delta.Abilities = &pb.AbilityTimers{Timers: next.timers}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return delta
+}
@@ -0,0 +1,100 @@
+package game
+
+import (
+	"server/pb"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDiff(t *testing.T) {
+	timer := &pb.AbilityTimer{Ability: 2, Remaining: 10, Stacks: 1}
+	s1 := entityState{positionX: 1, positionY: 2, velocityX: 3, velocityY: 4, rotation: 0.5}
+	s2 := entityState{positionX: 2, positionY: 2, velocityX: 3, velocityY: 4, rotation: 0.5}
+	s3 := entityState{positionX: 1, positionY: 2, velocityX: 3, velocityY: 4, rotation: 0}
+	s4 := s1
+	s4.score = 1
+	s4.flags = 2
+	s5 := s1
+	s5.abilities = []abilityState{{2, 20, 1}}
+	s5.timers = []*pb.AbilityTimer{timer}
+	s6 := s1
+	s6.abilities = []abilityState{{2, 20, 1}}
+	s7 := s1
+	s7.health = 75
+	s7.isInvulnerable = true
+	s8 := s1
+	s8.heat = 100
+	s8.isOverheated = true
+	s8.fireInterval = 6
+
+	rotation := 0.0
+	score := uint32(1)
+	flags := uint32(2)
+	health := uint32(75)
+	isInvulnerable := true
+	heat := uint32(100)
+	isOverheated := true
+	fireInterval := uint32(6)
+
+	tests := map[string]struct {
+		previous entityState
+		next     entityState
+		want     *pb.EntityDelta
+	}{
+		"diff with no changes": {s1, s1, nil},
+		"diff with changed position": {
+			s1,
+			s2,
+			&pb.EntityDelta{Id: "1", Position: &pb.Vector{X: 2, Y: 2}},
+		},
+		"diff with rotation changed to zero": {
+			s1,
+			s3,
+			&pb.EntityDelta{Id: "1", Rotation: &rotation},
+		},
+		"diff with changed player data": {
+			s1,
+			s4,
+			&pb.EntityDelta{Id: "1", Score: &score, Flags: &flags},
+		},
+		"diff with damaged player": {
+			s1,
+			s7,
+			&pb.EntityDelta{Id: "1", Health: &health, IsInvulnerable: &isInvulnerable},
+		},
+		"diff with overheated weapon": {
+			s1,
+			s8,
+			&pb.EntityDelta{
+				Id:           "1",
+				Heat:         &heat,
+				IsOverheated: &isOverheated,
+				FireInterval: &fireInterval,
+			},
+		},
+		"diff with new ability": {
+			s1,
+			s5,
+			&pb.EntityDelta{
+				Id:        "1",
+				Abilities: &pb.AbilityTimers{Timers: []*pb.AbilityTimer{timer}},
+			},
+		},
+		"diff with ability counting down": {s5, s6, nil},
+		"diff with expired ability": {
+			s5,
+			s1,
+			&pb.EntityDelta{Id: "1", Abilities: &pb.AbilityTimers{}},
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			got := test.previous.diff("1", test.next)
+			if !proto.Equal(got, test.want) {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package entities
 
 import (
 	"math/rand"
+	"server/internal/game/constants"
+	"server/pb"
 )
 
 // An AbilityFlag is a number where each bit corresponds to a particular
@@ -9,15 +11,167 @@ import (
 type AbilityFlag uint32
 
 const (
-	MultishotAbilityFlag AbilityFlag = 1 << 1 // shoots 3 bullets in parallel
-	WideBeamAbilityFlag  AbilityFlag = 1 << 2 // shoots 1 bullet
-	ShieldAbilityFlag    AbilityFlag = 1 << 3 // protects against 1 collision
+	MultishotAbilityFlag  AbilityFlag = 1 << 1 // shoots 3 bullets in parallel
+	WideBeamAbilityFlag   AbilityFlag = 1 << 2 // shoots 1 bullet
+	ShieldAbilityFlag     AbilityFlag = 1 << 3 // protects against 1 collision per stack
+	SpeedBoostAbilityFlag AbilityFlag = 1 << 4 // raises the max speed
+	HomingAbilityFlag     AbilityFlag = 1 << 5 // shots turn towards enemies
+	RapidFireAbilityFlag  AbilityFlag = 1 << 6 // shortens the time between shots per stack
+	PiercingAbilityFlag   AbilityFlag = 1 << 7 // shots pass through what they hit
 )
 
+// A StackingPolicy decides what happens when a player picks up an ability
+// which is already active.
+type StackingPolicy int
+
+const (
+	RefreshPolicy StackingPolicy = iota // restarts the timer
+	ExtendPolicy                        // adds the duration to the timer
+	StackPolicy                         // adds a stack and restarts the timer
+)
+
+// An ability describes how long an ability lasts and how it stacks.
+type ability struct {
+	flag      AbilityFlag
+	duration  uint32 // ticks
+	policy    StackingPolicy
+	maxTicks  uint32 // max ticks on the timer for ExtendPolicy
+	maxStacks uint32 // max stacks for StackPolicy
+}
+
+// abilityCatalog lists every ability which powerups can grant.
+var abilityCatalog = []ability{
+	{MultishotAbilityFlag, 20 * constants.FPS, RefreshPolicy, 0, 1},
+	{WideBeamAbilityFlag, 20 * constants.FPS, RefreshPolicy, 0, 1},
+	{ShieldAbilityFlag, 30 * constants.FPS, StackPolicy, 0, 3},
+	{SpeedBoostAbilityFlag, 10 * constants.FPS, ExtendPolicy, 30 * constants.FPS, 1},
+	{HomingAbilityFlag, 15 * constants.FPS, RefreshPolicy, 0, 1},
+	{RapidFireAbilityFlag, 10 * constants.FPS, StackPolicy, 0, 3},
+	{PiercingAbilityFlag, 15 * constants.FPS, ExtendPolicy, 30 * constants.FPS, 1},
+}
+
+// An abilityTimer tracks an active ability of a player.
+type abilityTimer struct {
+	remaining uint32 // ticks until the ability expires
+	stacks    uint32
+}
+
+// Abilities are the timed abilities which are active for a player. Abilities
+// are always visited in catalog order, so that timers are deterministic.
+type Abilities struct {
+	timers map[AbilityFlag]*abilityTimer
+}
+
+func NewAbilities() *Abilities {
+	return &Abilities{
+		timers: make(map[AbilityFlag]*abilityTimer),
+	}
+}
+
+// Add activates the ability with flag following its stacking policy.
+func (a *Abilities) Add(flag AbilityFlag) {
+	config, found := findAbility(flag)
+	if !found {
+		return
+	}
+
+	timer, isActive := a.timers[flag]
+	if !isActive {
+		a.timers[flag] = &abilityTimer{remaining: config.duration, stacks: 1}
+		return
+	}
+
+	switch config.policy {
+	case RefreshPolicy:
+		timer.remaining = config.duration
+	case ExtendPolicy:
+		timer.remaining = min(timer.remaining+config.duration, config.maxTicks)
+	case StackPolicy:
+		timer.remaining = config.duration
+		timer.stacks = min(timer.stacks+1, config.maxStacks)
+	}
+}
+
+// Consume removes a single stack of the ability with flag. It reports whether
+// there was a stack to remove.
+func (a *Abilities) Consume(flag AbilityFlag) bool {
+	timer, isActive := a.timers[flag]
+	if !isActive {
+		return false
+	}
+
+	timer.stacks--
+	if timer.stacks == 0 {
+		delete(a.timers, flag)
+	}
+	return true
+}
+
+// Update counts down each timer by one tick, and expires abilities which have
+// run out of time.
+func (a *Abilities) Update() {
+	for _, config := range abilityCatalog {
+		timer, isActive := a.timers[config.flag]
+		if !isActive {
+			continue
+		}
+
+		timer.remaining--
+		if timer.remaining == 0 {
+			delete(a.timers, config.flag)
+		}
+	}
+}
+
+// GetStacks returns the number of stacks of the ability with flag, or 0 if it
+// is not active.
+func (a *Abilities) GetStacks(flag AbilityFlag) uint32 {
+	timer, isActive := a.timers[flag]
+	if !isActive {
+		return 0
+	}
+	return timer.stacks
+}
+
+// GetFlags returns the flags of every active ability.
+func (a *Abilities) GetFlags() AbilityFlag {
+	flags := AbilityFlag(0)
+	for flag := range a.timers {
+		flags |= flag
+	}
+	return flags
+}
+
+// ToPb serializes the active abilities in catalog order.
+func (a *Abilities) ToPb() []*pb.AbilityTimer {
+	timers := []*pb.AbilityTimer{}
+	for _, config := range abilityCatalog {
+		timer, isActive := a.timers[config.flag]
+		if !isActive {
+			continue
+		}
+		timers = append(timers, &pb.AbilityTimer{
+			Ability:   uint32(config.flag),
+			Remaining: timer.remaining,
+			Stacks:    timer.stacks,
+		})
+	}
+	return timers
+}
+
+func findAbility(flag AbilityFlag) (ability, bool) {
+	for _, config := range abilityCatalog {
+		if config.flag == flag {
+			return config, true
+		}
+	}
+	return ability{}, false
+}
+
 func isAbilityActive(flags AbilityFlag, ability AbilityFlag) bool {
 	return (flags & ability) != 0
 }
 
-func newRandomAbility() AbilityFlag {
-	return AbilityFlag(1 << (1 + rand.Intn(3)))
+func newRandomAbility(random *rand.Rand) AbilityFlag {
+	return abilityCatalog[random.Intn(len(abilityCatalog))].flag
 }
@@ -1,8 +1,13 @@
 package entities
 
 import (
+	"cmp"
+	"math"
+	"math/rand"
 	"server/internal/game/geometry"
+	"server/internal/id"
 	"server/pb"
+	"slices"
 )
 
 const (
@@ -14,6 +19,11 @@ const (
 	ASTEROID_MAX_RADIUS     = 100
 	ASTEROID_MIN_AREA       = 200
 	ASTEROID_MAX_HEALTH     = 3
+
+	ASTEROID_MIN_FRAGMENTS   = 2
+	ASTEROID_MAX_FRAGMENTS   = 3
+	ASTEROID_FRAGMENT_SPREAD = 0.6 // max speed of fragments away from the center
+	ASTEROID_FRAGMENT_HEALTH = 1
 )
 
 // An Asteroid is an obstacle. Its shape is a randomly generated convex hull.
@@ -22,6 +32,9 @@ const (
 //   - drifts slowly
 //   - rotates
 //   - takes 3 shots to destroy
+//   - splits into smaller fragments when destroyed by shots
+//   - passes through other asteroids, so that fragments do not destroy each
+//     other along the edges they were cut from
 type Asteroid struct {
 	entityData *pb.EntityData
 
@@ -31,8 +44,12 @@ type Asteroid struct {
 	rotation float64
 
 	boundingBox *geometry.BoundingBox
+	points      []*geometry.Vector
 	spin        float64
 	health      int
+	fragments   []Entity      // created when destroyed, until polled
+	random      *rand.Rand    // shapes the fragments
+	ids         *id.Generator // generates IDs for fragments
 }
 
 func newAsteroid(
@@ -40,11 +57,14 @@ func newAsteroid(
 	position geometry.Vector,
 	velocity geometry.Vector,
 	rotation float64,
-	points *[]*geometry.Vector,
+	points []*geometry.Vector,
 	spin float64,
+	health int,
+	random *rand.Rand,
+	ids *id.Generator,
 ) *Asteroid {
-	entityPoints := make([]*pb.Vector, len(*points))
-	for i, point := range *points {
+	entityPoints := make([]*pb.Vector, len(points))
+	for i, point := range points {
 		entityPoints[i] = point.ToPb()
 	}
 	entityData := &pb.EntityData{
@@ -65,10 +85,14 @@ func newAsteroid(
 		position:   position,
 		velocity:   velocity,
 		rotation:   rotation,
+		points:     points,
 		spin:       spin,
-		health:     ASTEROID_MAX_HEALTH,
+		health:     health,
+		fragments:  nil,
+		random:     random,
+		ids:        ids,
 	}
-	a.boundingBox = geometry.NewBoundingBox(&a.position, &a.rotation, points)
+	a.boundingBox = geometry.NewBoundingBox(&a.position, &a.rotation, &a.points)
 	return a
 }
 
@@ -109,8 +133,17 @@ func (a *Asteroid) Update() bool {
 	return true
 }
 
+func (a *Asteroid) Move(position geometry.Vector, velocity geometry.Vector) {
+	a.position = position
+	a.velocity = velocity
+	a.entityData.Velocity = velocity.ToPb()
+	a.SyncEntityData()
+}
+
 func (a *Asteroid) PollNewEntities() []Entity {
-	return nil
+	fragments := a.fragments
+	a.fragments = nil
+	return fragments
 }
 
 func (a *Asteroid) UpdateOnCollision(other Entity) {}
@@ -119,11 +152,17 @@ func (a *Asteroid) RemoveOnCollision(other Entity) bool {
 	switch other.GetEntityType() {
 	case pb.EntityType_ENTITY_TYPE_PROJECTILE:
 		a.health--
+		if a.health == 0 {
+			a.fragments = a.split()
+		}
 		return a.health <= 0
 
 	case pb.EntityType_ENTITY_TYPE_POWERUP:
 		return false
 
+	case pb.EntityType_ENTITY_TYPE_ASTEROID:
+		return false
+
 	default:
 		return true
 	}
@@ -134,3 +173,75 @@ func (a *Asteroid) SyncEntityData() {
 	a.entityData.Position.Y = a.position.Y
 	a.entityData.Rotation = a.rotation
 }
+
+// split breaks the asteroid into fragments by cutting its hull through the
+// center of its largest piece, until there are enough pieces. Each fragment
+// keeps the asteroid's momentum and drifts away from where the asteroid was.
+// Fragments smaller than ASTEROID_MIN_AREA are dropped.
+func (a *Asteroid) split() []Entity {
+	count := ASTEROID_MIN_FRAGMENTS +
+		a.random.Intn(ASTEROID_MAX_FRAGMENTS-ASTEROID_MIN_FRAGMENTS+1)
+
+	hulls := [][]*geometry.Vector{a.points}
+	for len(hulls) < count {
+		slices.SortStableFunc(hulls, func(u, v []*geometry.Vector) int {
+			return cmp.Compare(geometry.HullArea(v), geometry.HullArea(u))
+		})
+
+		largest := hulls[0]
+		center := geometry.HullCentroid(largest)
+		angle := a.random.Float64() * math.Pi
+		direction := geometry.NewVector(math.Cos(angle), math.Sin(angle))
+		left, right := geometry.CutHull(largest, &center, direction)
+		hulls = append(hulls[1:], left, right)
+	}
+
+	fragments := []Entity{}
+	for _, hull := range hulls {
+		if len(hull) < 3 || geometry.HullArea(hull) < ASTEROID_MIN_AREA {
+			continue
+		}
+
+		fragment, err := a.newFragment(hull)
+		if err != nil {
+			continue
+		}
+		fragments = append(fragments, fragment)
+	}
+	return fragments
+}
+
+// newFragment creates an asteroid from hull, which is a piece of the
+// asteroid's hull. The fragment is centered on the center of hull.
+func (a *Asteroid) newFragment(hull []*geometry.Vector) (*Asteroid, error) {
+	id, err := a.ids.NewShortId()
+	if err != nil {
+		return nil, err
+	}
+
+	center := geometry.HullCentroid(hull)
+	points := make([]*geometry.Vector, len(hull))
+	for i, point := range hull {
+		points[i] = point.Sub(&center)
+	}
+
+	offset := center.Rotate(a.rotation)
+	position := a.position.Add(offset)
+	spread := offset.Unit().Multiply(
+		ASTEROID_FRAGMENT_SPREAD * (0.5 + a.random.Float64()/2),
+	)
+	velocity := a.velocity.Add(spread)
+	spin := a.spin + a.random.Float64()*ASTEROID_MAX_SPIN*2 - ASTEROID_MAX_SPIN
+
+	return newAsteroid(
+		id,
+		*position,
+		*velocity,
+		a.rotation,
+		points,
+		spin,
+		ASTEROID_FRAGMENT_HEALTH,
+		a.random,
+		a.ids,
+	), nil
+}
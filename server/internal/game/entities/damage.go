@@ -0,0 +1,28 @@
+package entities
+
+import "server/pb"
+
+const (
+	PROJECTILE_DAMAGE = 25
+	WIDE_BEAM_DAMAGE  = 40
+	ASTEROID_DAMAGE   = 50 // damage from ramming an asteroid
+	PLAYER_DAMAGE     = 34 // damage from ramming another player
+)
+
+// getDamage returns the damage dealt by other on impact, the cause of the
+// damage, and the ID of the player responsible for it, if any.
+func getDamage(other Entity) (uint32, pb.DamageCause, string) {
+	switch e := other.(type) {
+	case *Projectile:
+		if isAbilityActive(e.GetFlags(), WideBeamAbilityFlag) {
+			return WIDE_BEAM_DAMAGE, pb.DamageCause_DAMAGE_CAUSE_WIDE_BEAM, e.GetOwnerId()
+		}
+		return PROJECTILE_DAMAGE, pb.DamageCause_DAMAGE_CAUSE_PROJECTILE, e.GetOwnerId()
+	case *Asteroid:
+		return ASTEROID_DAMAGE, pb.DamageCause_DAMAGE_CAUSE_ASTEROID, ""
+	case *Player:
+		return PLAYER_DAMAGE, pb.DamageCause_DAMAGE_CAUSE_PLAYER, e.GetId()
+	default:
+		return 0, pb.DamageCause_DAMAGE_CAUSE_UNKNOWN, ""
+	}
+}
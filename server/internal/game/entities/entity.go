@@ -24,6 +24,10 @@ type Entity interface {
 
 	Update() bool
 
+	// Move places the entity at position with velocity, without any of its
+	// usual movement rules (e.g. at the edge of the world).
+	Move(position geometry.Vector, velocity geometry.Vector)
+
 	// PollNewEntities returns any entities created by the entity.
 	// (e.g. player shooting a projectile).
 	PollNewEntities() []Entity
@@ -19,9 +19,9 @@ func NewMockEntity(
 	rotation float64,
 	points []*geometry.Vector,
 ) *MockEntity {
-	position := geometry.NewVector(x, y)
-	boundingBox := geometry.NewBoundingBox(position, &rotation, &points)
-	return &MockEntity{Id: id, position: *position, boundingBox: boundingBox}
+	e := &MockEntity{Id: id, position: *geometry.NewVector(x, y)}
+	e.boundingBox = geometry.NewBoundingBox(&e.position, &rotation, &points)
+	return e
 }
 
 func (e *MockEntity) GetEntityType() pb.EntityType {
@@ -56,6 +56,10 @@ func (e *MockEntity) Update() bool {
 	return false
 }
 
+func (e *MockEntity) Move(position geometry.Vector, velocity geometry.Vector) {
+	e.position = position
+}
+
 func (e *MockEntity) PollNewEntities() []Entity {
 	return nil
 }
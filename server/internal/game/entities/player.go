@@ -2,6 +2,7 @@ package entities
 
 import (
 	"math"
+	"server/internal/game/constants"
 	"server/internal/game/geometry"
 	"server/internal/id"
 	"server/pb"
@@ -13,6 +14,11 @@ const (
 	PLAYER_MAX_TURN_RATE          = 0.8
 	PLAYER_TURN_RATE_DECAY_FACTOR = 4.0
 	PLAYER_RADIUS                 = 40.0
+	PLAYER_MAX_HEALTH             = 100
+	PLAYER_SPAWN_INVULNERABILITY  = 3 * constants.FPS // ticks without damage after spawning
+	PLAYER_RAM_INTERVAL           = constants.FPS / 2 // min ticks between ramming damage
+
+	SPEED_BOOST_FACTOR = 1.5 // max speed multiplier with a speed boost
 )
 
 var playerBoundingBoxPoints = geometry.NewRectangleHull(
@@ -23,10 +29,11 @@ var playerBoundingBoxPoints = geometry.NewRectangleHull(
 // A Player is the spaceship which players control.
 //
 // Behaviors:
-//   - destroyed on impact with an asteroid, projectile or another player
-//   - can shoot projectiles
-//   - can collect powerups
-//   - takes 1 shot to destroy
+//   - damaged on impact with an asteroid, projectile or another player
+//   - can shoot projectiles, limited by a cooldown and weapon heat
+//   - can collect powerups, whose abilities last for a limited time
+//   - destroyed once its health runs out
+//   - cannot be damaged for a while after spawning
 type Player struct {
 	entityData *pb.EntityData
 
@@ -39,6 +46,15 @@ type Player struct {
 	mouseX       float64
 	mouseY       float64
 	mousePressed bool
+	tick         uint32        // latest tick seen by the client
+	ids          *id.Generator // generates IDs for projectiles
+	abilities    *Abilities
+	cooldown     uint32 // ticks until the player can fire again
+
+	invulnerability uint32         // ticks until the player can be damaged
+	ramCooldown     uint32         // ticks until ramming can damage the player
+	killerId        string         // player responsible for the latest damage
+	cause           pb.DamageCause // cause of the latest damage
 }
 
 func newPlayer(
@@ -47,6 +63,7 @@ func newPlayer(
 	velocity geometry.Vector,
 	rotation float64,
 	username string,
+	ids *id.Generator,
 ) *Player {
 	mouseX := 0.0
 	mouseY := 0.0
@@ -60,9 +77,15 @@ func newPlayer(
 		Rotation: rotation,
 		Data: &pb.EntityData_PlayerData_{
 			PlayerData: &pb.EntityData_PlayerData{
-				Username: username,
-				Score:    0,
-				Flags:    0,
+				Username:       username,
+				Score:          0,
+				Flags:          0,
+				Abilities:      []*pb.AbilityTimer{},
+				Health:         PLAYER_MAX_HEALTH,
+				IsInvulnerable: true,
+				Heat:           0,
+				IsOverheated:   false,
+				FireInterval:   basicWeapon.fireInterval,
 			},
 		},
 	}
@@ -75,6 +98,14 @@ func newPlayer(
 		mouseX:       mouseX,
 		mouseY:       mouseY,
 		mousePressed: mousePressed,
+		ids:          ids,
+		abilities:    NewAbilities(),
+		cooldown:     0,
+
+		invulnerability: PLAYER_SPAWN_INVULNERABILITY,
+		ramCooldown:     0,
+		killerId:        "",
+		cause:           pb.DamageCause_DAMAGE_CAUSE_UNKNOWN,
 	}
 	p.boundingBox = geometry.NewBoundingBox(
 		&p.position,
@@ -112,36 +143,122 @@ func (p *Player) GetBoundingBox() *geometry.BoundingBox {
 	return p.boundingBox
 }
 
+func (p *Player) GetTeam() uint32 {
+	return p.entityData.GetPlayerData().Team
+}
+
+func (p *Player) SetTeam(team uint32) {
+	p.entityData.GetPlayerData().Team = team
+}
+
+func (p *Player) GetHealth() uint32 {
+	return p.entityData.GetPlayerData().Health
+}
+
+// GetKillerId returns the ID of the player responsible for the latest damage,
+// or an empty string if no player was.
+func (p *Player) GetKillerId() string {
+	return p.killerId
+}
+
+// GetCause returns the cause of the latest damage.
+func (p *Player) GetCause() pb.DamageCause {
+	return p.cause
+}
+
+// AddScore increments the player's score, e.g. after a kill.
+func (p *Player) AddScore() {
+	p.entityData.GetPlayerData().Score++
+}
+
+// Damage reduces the player's health by damage from cause, which killerId
+// is responsible for. Damage is ignored while the player is invulnerable. It
+// reports whether the player ran out of health.
+func (p *Player) Damage(damage uint32, cause pb.DamageCause, killerId string) bool {
+	if p.invulnerability > 0 {
+		return false
+	}
+
+	data := p.entityData.GetPlayerData()
+	data.Health -= min(damage, data.Health)
+	p.killerId = killerId
+	p.cause = cause
+	return data.Health == 0
+}
+
 func (p *Player) Update() bool {
 	// TODO: continue iterating on this
 	targetVelocity := geometry.NewVector(p.mouseX, p.mouseY)
 
-	speed := accelerate(p.velocity, targetVelocity)
+	maxSpeed := PLAYER_MAX_SPEED
+	if p.abilities.GetStacks(SpeedBoostAbilityFlag) > 0 {
+		maxSpeed *= SPEED_BOOST_FACTOR
+	}
+	speed := accelerate(p.velocity, targetVelocity, maxSpeed)
 	p.velocity.X = math.Cos(p.rotation) * speed
 	p.velocity.Y = math.Sin(p.rotation) * speed
 
 	p.position.X += p.velocity.X
 	p.position.Y += p.velocity.Y
 	p.rotation = rotate(p.velocity, targetVelocity)
+	p.abilities.Update()
+	if p.invulnerability > 0 {
+		p.invulnerability--
+	}
+	if p.ramCooldown > 0 {
+		p.ramCooldown--
+	}
+	p.cool()
 
 	p.SyncEntityData()
 	return true
 }
 
+// Move places the player at position with velocity. The player turns to face
+// along velocity, since players always fly forwards.
+func (p *Player) Move(position geometry.Vector, velocity geometry.Vector) {
+	p.position = position
+	p.velocity = velocity
+	if velocity.Length() > 0 {
+		p.rotation = velocity.Angle()
+	}
+	p.SyncEntityData()
+}
+
+// PollNewEntities shoots a volley when the player fires. Shots are dropped
+// while the weapon is cooling down or overheated, so that the fire rate does
+// not depend on how often the client sends inputs.
 func (p *Player) PollNewEntities() []Entity {
 	if !p.mousePressed {
 		return nil
 	}
 	p.mousePressed = false
 
+	data := p.entityData.GetPlayerData()
+	if p.cooldown > 0 || data.IsOverheated {
+		return nil
+	}
+
+	weapon := chooseWeapon(p.abilities.GetFlags())
+	p.cooldown = p.getFireInterval()
+	data.Heat = min(data.Heat+weapon.heat, PLAYER_MAX_HEAT)
+	data.IsOverheated = data.Heat == PLAYER_MAX_HEAT
 	return p.spawnProjectiles()
 }
 
+// getFireInterval returns the min ticks between shots for the current weapon.
+// Each stack of rapid fire shortens the interval.
+func (p *Player) getFireInterval() uint32 {
+	weapon := chooseWeapon(p.abilities.GetFlags())
+	return max(weapon.fireInterval/(1+p.abilities.GetStacks(RapidFireAbilityFlag)), 1)
+}
+
 func (p *Player) UpdateOnCollision(other Entity) {
 	if other.GetEntityType() == pb.EntityType_ENTITY_TYPE_POWERUP {
 		powerup := other.(*Powerup)
 		ability := powerup.entityData.GetPowerupData().Ability
-		p.entityData.GetPlayerData().Flags |= ability
+		p.abilities.Add(AbilityFlag(ability))
+		p.SyncEntityData()
 	}
 }
 
@@ -150,20 +267,33 @@ func (p *Player) RemoveOnCollision(other Entity) bool {
 		return false
 	}
 
-	flags := AbilityFlag(p.entityData.GetPlayerData().Flags)
-	if isAbilityActive(flags, ShieldAbilityFlag) {
-		p.entityData.GetPlayerData().Flags ^= uint32(ShieldAbilityFlag)
-		return false
+	damage, cause, killerId := getDamage(other)
+	if cause == pb.DamageCause_DAMAGE_CAUSE_PLAYER {
+		// Players stay in contact for several ticks when ramming each other.
+		if p.ramCooldown > 0 {
+			return false
+		}
+		p.ramCooldown = PLAYER_RAM_INTERVAL
 	}
 
-	return true
+	if p.invulnerability == 0 && p.abilities.Consume(ShieldAbilityFlag) {
+		p.SyncEntityData()
+		return false
+	}
+	return p.Damage(damage, cause, killerId)
 }
 
-func (p *Player) Input(mouseX float64, mouseY float64, mousePressed bool) {
+func (p *Player) Input(
+	mouseX float64,
+	mouseY float64,
+	mousePressed bool,
+	tick uint32,
+) {
 	// mouseX and mouseY are normalized (i.e. range is [0.0, 1.0])
 	p.mouseX = mouseX
 	p.mouseY = mouseY
 	p.mousePressed = p.mousePressed || mousePressed
+	p.tick = max(p.tick, tick)
 }
 
 // spawnProjectiles creates a volley of projectiles based on the player's
@@ -192,8 +322,11 @@ func (p *Player) spawnProjectiles() []Entity {
 // spawnProjectile creates a single projectile with a given offset. Offset is
 // the perpendicular distance between the player's velocity and position of the
 // projectile.
+//
+// The projectile records the latest tick seen by the client, so that its hits
+// can be checked against the game as the player saw it when firing.
 func (p *Player) spawnProjectile(offset float64) (*Projectile, error) {
-	id, err := id.NewShortId()
+	id, err := p.ids.NewShortId()
 	if err != nil {
 		return nil, err
 	}
@@ -209,19 +342,22 @@ func (p *Player) spawnProjectile(offset float64) (*Projectile, error) {
 		*position,
 		*velocity,
 		AbilityFlag(p.entityData.GetPlayerData().Flags),
-		p.projectileOnRemove,
+		p.GetId(),
+		p.tick,
 	), nil
 }
 
-// projectileOnRemove is a callback when the projectile is destroyed. It
-// increments the player's score if the shot hit another player.
-func (p *Player) projectileOnRemove(other *Entity) {
-	if other == nil {
-		return
+// cool counts down the cooldown and lowers the weapon heat. An overheated
+// weapon can fire again once it has fully cooled.
+func (p *Player) cool() {
+	if p.cooldown > 0 {
+		p.cooldown--
 	}
 
-	if (*other).GetEntityType() == pb.EntityType_ENTITY_TYPE_PLAYER {
-		p.entityData.GetPlayerData().Score++
+	data := p.entityData.GetPlayerData()
+	data.Heat -= min(HEAT_COOLING, data.Heat)
+	if data.Heat == 0 {
+		data.IsOverheated = false
 	}
 }
 
@@ -244,9 +380,10 @@ func rotate(
 func accelerate(
 	currentVelocity geometry.Vector,
 	targetVelocity *geometry.Vector,
+	maxSpeed float64,
 ) float64 {
 	currentSpeed := currentVelocity.Length()
-	targetSpeed := targetVelocity.Length() * PLAYER_MAX_SPEED
+	targetSpeed := targetVelocity.Length() * maxSpeed
 
 	acceleration := targetSpeed - currentSpeed
 	decay := 1 / (1 + PLAYER_ACCELERATION_DECAY*currentSpeed)
@@ -270,4 +407,8 @@ func (p *Player) SyncEntityData() {
 	p.entityData.Velocity.X = p.velocity.X
 	p.entityData.Velocity.Y = p.velocity.Y
 	p.entityData.Rotation = p.rotation
+	p.entityData.GetPlayerData().Flags = uint32(p.abilities.GetFlags())
+	p.entityData.GetPlayerData().Abilities = p.abilities.ToPb()
+	p.entityData.GetPlayerData().IsInvulnerable = p.invulnerability > 0
+	p.entityData.GetPlayerData().FireInterval = p.getFireInterval()
 }
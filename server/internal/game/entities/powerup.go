@@ -59,8 +59,8 @@ func newPowerup(
 		rotation:   rotation,
 	}
 	p.boundingBox = geometry.NewBoundingBox(
-		&position,
-		&rotation,
+		&p.position,
+		&p.rotation,
 		&powerupBoundingBoxPoints,
 	)
 	return &p
@@ -98,6 +98,11 @@ func (p *Powerup) Update() bool {
 	return false
 }
 
+func (p *Powerup) Move(position geometry.Vector, velocity geometry.Vector) {
+	p.position = position
+	p.entityData.Position = position.ToPb()
+}
+
 func (p *Powerup) PollNewEntities() []Entity {
 	return nil
 }
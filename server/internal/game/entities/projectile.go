@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"math"
 	"server/internal/game/constants"
 	"server/internal/game/geometry"
 	"server/pb"
@@ -10,16 +11,20 @@ const (
 	PROJECTILE_RADIUS   = 10.0
 	PROJECTILE_SPEED    = 24.0
 	PROJECTILE_LIFETIME = 2.4 * constants.FPS
+
+	HOMING_RANGE     = 1200.0 // max distance to lock on to a target
+	HOMING_TURN_RATE = 0.06   // max radians turned per tick
 )
 
 var basicProjectileBoundingBoxPoints = geometry.NewRectangleHull(10, 10)
 var wideBeamProjectileBoundingBoxPoints = geometry.NewRectangleHull(20, 80)
+var piercingProjectileBoundingBoxPoints = geometry.NewRectangleHull(60, 8)
 
 // A Projectile is a bullet.
 //
 // Behaviors:
-//   - destroyed on impact with an asteroid or another player
-//   - can move, but with a constant trajectory
+//   - destroyed on impact with an asteroid or another player, unless piercing
+//   - can move, with a constant trajectory unless homing
 type Projectile struct {
 	entityData *pb.EntityData
 
@@ -29,18 +34,18 @@ type Projectile struct {
 	rotation float64
 
 	boundingBox *geometry.BoundingBox
-	onRemove    ProjectileOnRemoveCallback
+	ownerId     string          // id of the player that fired the projectile
+	tick        uint32          // tick seen by the owner when firing
+	hits        map[string]bool // ids of entities hit while piercing
 }
 
-// A ProjectileOnRemoveCallback is a callback to handle a collision with other.
-type ProjectileOnRemoveCallback func(other *Entity)
-
 func newProjectile(
 	id string,
 	position geometry.Vector,
 	velocity geometry.Vector,
 	flags AbilityFlag,
-	onRemove ProjectileOnRemoveCallback,
+	ownerId string,
+	tick uint32,
 ) *Projectile {
 	rotation := velocity.Angle()
 	points := chooseBoundingBoxPoints(AbilityFlag(flags))
@@ -64,7 +69,9 @@ func newProjectile(
 		position:   position,
 		velocity:   velocity,
 		rotation:   rotation,
-		onRemove:   onRemove,
+		ownerId:    ownerId,
+		tick:       tick,
+		hits:       make(map[string]bool),
 	}
 	p.boundingBox = geometry.NewBoundingBox(&p.position, &p.rotation, points)
 	return &p
@@ -90,12 +97,26 @@ func (p *Projectile) GetVelocity() geometry.Vector {
 	return p.velocity
 }
 
+func (p *Projectile) GetOwnerId() string {
+	return p.ownerId
+}
+
+func (p *Projectile) GetTick() uint32 {
+	return p.tick
+}
+
+func (p *Projectile) GetFlags() AbilityFlag {
+	return AbilityFlag(p.entityData.GetProjectileData().Flags)
+}
+
+// HasHit reports whether the projectile already hit the entity with id, so
+// that piercing projectiles only hit each entity once.
+func (p *Projectile) HasHit(id string) bool {
+	return p.hits[id]
+}
+
 func (p *Projectile) GetIsExpired() bool {
-	if p.entityData.GetProjectileData().Lifetime < 0 {
-		p.onRemove(nil)
-		return true
-	}
-	return false
+	return p.entityData.GetProjectileData().Lifetime < 0
 }
 
 func (p *Projectile) GetBoundingBox() *geometry.BoundingBox {
@@ -111,6 +132,28 @@ func (p *Projectile) Update() bool {
 	return true
 }
 
+func (p *Projectile) Move(position geometry.Vector, velocity geometry.Vector) {
+	p.position = position
+	p.velocity = velocity
+	p.rotation = velocity.Angle()
+	p.entityData.Velocity = velocity.ToPb()
+	p.entityData.Rotation = p.rotation
+	p.SyncEntityData()
+}
+
+// SteerTowards turns the projectile towards target by at most
+// HOMING_TURN_RATE, keeping its speed.
+func (p *Projectile) SteerTowards(target geometry.Vector) {
+	direction := target.Sub(&p.position)
+	angle := normalizeAngle(direction.Angle() - p.rotation)
+	angle = math.Max(-HOMING_TURN_RATE, math.Min(angle, HOMING_TURN_RATE))
+
+	rotation := p.rotation + angle
+	speed := p.velocity.Length()
+	velocity := geometry.NewVector(math.Cos(rotation)*speed, math.Sin(rotation)*speed)
+	p.Move(p.position, *velocity)
+}
+
 func (p *Projectile) PollNewEntities() []Entity {
 	return nil
 }
@@ -118,11 +161,14 @@ func (p *Projectile) PollNewEntities() []Entity {
 func (p *Projectile) UpdateOnCollision(other Entity) {}
 
 func (p *Projectile) RemoveOnCollision(other Entity) bool {
-	p.onRemove(&other)
+	p.hits[other.GetId()] = true
 
 	switch other.GetEntityType() {
 	case pb.EntityType_ENTITY_TYPE_PLAYER:
-		return true
+		return !isAbilityActive(p.GetFlags(), PiercingAbilityFlag)
+
+	case pb.EntityType_ENTITY_TYPE_ASTEROID:
+		return !isAbilityActive(p.GetFlags(), PiercingAbilityFlag)
 
 	case pb.EntityType_ENTITY_TYPE_POWERUP:
 		return false
@@ -136,6 +182,9 @@ func (p *Projectile) RemoveOnCollision(other Entity) bool {
 }
 
 func chooseBoundingBoxPoints(flags AbilityFlag) *[]*geometry.Vector {
+	if isAbilityActive(flags, PiercingAbilityFlag) {
+		return &piercingProjectileBoundingBoxPoints
+	}
 	if isAbilityActive(flags, WideBeamAbilityFlag) {
 		return &wideBeamProjectileBoundingBoxPoints
 	}
@@ -1,58 +1,82 @@
 package entities
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
-	"server/internal/game/constants"
 	"server/internal/game/geometry"
 	"server/internal/id"
 )
 
 const (
-	SPAWN_AREA_WIDTH  = 10000.0
-	SPAWN_AREA_HEIGHT = 10000.0
-	GRID_SIZE         = 96
+	DEFAULT_WORLD_SIZE = 10000.0
+	GRID_SIZE          = 96
 
 	INITIAL_ASTEROID_COUNT = 32
 	INITIAL_POWERUP_COUNT  = 3
 
-	ASTEROID_SPAWN_INTERVAL = 60 * constants.FPS
-	POWERUP_SPAWN_INTERVAL  = 20 * constants.FPS
-	RESET_INTERVAL          = 5 * 60 * constants.FPS
+	SPAWN_ATTEMPTS = 32 // candidate positions to try for each entity
 )
 
-// A Spawner is responsible for spawning new entities into the game. It used
-// the current frame count to decide when to spawn a new entity.
+// ErrNoSpawnPoint is returned when none of the candidate positions for a new
+// entity are safe.
+var ErrNoSpawnPoint = errors.New("no safe spawn point")
+
+// A SpawnCheck reports whether entity can safely be spawned at its current
+// position.
+type SpawnCheck func(entity Entity) bool
+
+// A Spawner is responsible for spawning new entities into the game. The game
+// decides when to spawn new entities. All randomness is drawn from random, so
+// spawners with identically seeded sources spawn the same entities with the
+// same IDs. Entities are spawned within a square world of worldSize, at the
+// first of several random candidate positions which the game finds safe.
 type Spawner struct {
-	counter int // frame count
+	random    *rand.Rand
+	ids       *id.Generator
+	worldSize float64
 }
 
-func NewSpawner() Spawner {
+func NewSpawner(random *rand.Rand, worldSize float64) Spawner {
 	return Spawner{
-		counter: 0,
+		random:    random,
+		ids:       id.NewGenerator(id.NAMESPACE_GAME),
+		worldSize: worldSize,
 	}
 }
 
-func (s *Spawner) SpawnPlayer(id string, username string) (*Player, error) {
-	position := *geometry.NewRandomVector(
-		0,
-		0,
-		SPAWN_AREA_WIDTH,
-		SPAWN_AREA_HEIGHT,
-	)
+// NewId returns a new entity ID, for players which are not clients.
+func (s *Spawner) NewId() (string, error) {
+	return s.ids.NewShortId()
+}
+
+// SpawnPlayer creates a player with username at a safe position.
+func (s *Spawner) SpawnPlayer(
+	id string,
+	username string,
+	isSafe SpawnCheck,
+) (*Player, error) {
 	velocity := *geometry.NewVector(0, 0)
 	rotation := 0.0
-	return newPlayer(id, position, velocity, rotation, username), nil
+	player := newPlayer(id, geometry.Vector{}, velocity, rotation, username, s.ids)
+	err := s.place(player, s.samplePosition, isSafe)
+	if err != nil {
+		return nil, err
+	}
+	return player, nil
 }
 
-func (s *Spawner) spawnRandomAsteroid() (*Asteroid, error) {
-	id, err := id.NewShortId()
+// SpawnAsteroid creates an asteroid with a random shape and velocity at a safe
+// position.
+func (s *Spawner) SpawnAsteroid(isSafe SpawnCheck) (*Asteroid, error) {
+	id, err := s.ids.NewShortId()
 	if err != nil {
 		return nil, err
 	}
 
 	points := geometry.NewRandomConvexHull(
+		s.random,
 		ASTEROID_MIN_NUM_POINTS,
 		ASTEROID_MAX_NUM_POINTS,
 		ASTEROID_MIN_RADIUS,
@@ -62,77 +86,82 @@ func (s *Spawner) spawnRandomAsteroid() (*Asteroid, error) {
 		return nil, fmt.Errorf("too small")
 	}
 
-	position := *geometry.NewRandomVector(
-		0,
-		0,
-		SPAWN_AREA_WIDTH,
-		SPAWN_AREA_HEIGHT,
-	)
 	velocity := *geometry.NewRandomVector(
+		s.random,
 		0,
 		0,
 		ASTEROID_MAX_SPEED,
 		ASTEROID_MAX_SPEED,
 	)
-	rotation := rand.Float64() * math.Pi * 2
-	spin := rand.Float64()*ASTEROID_MAX_SPIN*2 - ASTEROID_MAX_SPIN
-	return newAsteroid(id, position, velocity, rotation, &points, spin), nil
+	rotation := s.random.Float64() * math.Pi * 2
+	spin := s.random.Float64()*ASTEROID_MAX_SPIN*2 - ASTEROID_MAX_SPIN
+	asteroid := newAsteroid(
+		id,
+		geometry.Vector{},
+		velocity,
+		rotation,
+		points,
+		spin,
+		ASTEROID_MAX_HEALTH,
+		s.random,
+		s.ids,
+	)
+	err = s.place(asteroid, s.samplePosition, isSafe)
+	if err != nil {
+		return nil, err
+	}
+	return asteroid, nil
 }
 
-func (s *Spawner) spawnPowerup() (*Powerup, error) {
-	id, err := id.NewShortId()
+// SpawnPowerup creates a powerup with a random ability at a safe position on
+// the grid.
+func (s *Spawner) SpawnPowerup(isSafe SpawnCheck) (*Powerup, error) {
+	id, err := s.ids.NewShortId()
 	if err != nil {
 		return nil, err
 	}
 
-	position := *geometry.NewRandomVector(
-		0,
-		0,
-		SPAWN_AREA_WIDTH,
-		SPAWN_AREA_HEIGHT,
-	)
-	position.X = math.Round(position.X/GRID_SIZE) * GRID_SIZE
-	position.Y = math.Round(position.Y/GRID_SIZE) * GRID_SIZE
-	ability := newRandomAbility()
-	return newPowerup(id, position, ability), nil
+	ability := newRandomAbility(s.random)
+	powerup := newPowerup(id, geometry.Vector{}, ability)
+	err = s.place(powerup, s.sampleGridPosition, isSafe)
+	if err != nil {
+		return nil, err
+	}
+	return powerup, nil
 }
 
-func (s *Spawner) InitEntities() []Entity {
-	entities := []Entity{}
-
-	for range INITIAL_ASTEROID_COUNT {
-		asteroid, err := s.spawnRandomAsteroid()
-		if err == nil {
-			entities = append(entities, asteroid)
-		}
-	}
-	for range INITIAL_POWERUP_COUNT {
-		powerup, err := s.spawnPowerup()
-		if err == nil {
-			entities = append(entities, powerup)
+// place moves entity to candidate positions drawn from sample, until isSafe
+// accepts one. It returns ErrNoSpawnPoint after SPAWN_ATTEMPTS candidates.
+func (s *Spawner) place(
+	entity Entity,
+	sample func() geometry.Vector,
+	isSafe SpawnCheck,
+) error {
+	for range SPAWN_ATTEMPTS {
+		entity.Move(sample(), entity.GetVelocity())
+		if isSafe(entity) {
+			return nil
 		}
 	}
-
-	s.counter = (s.counter + 1) % RESET_INTERVAL
-	return entities
+	return ErrNoSpawnPoint
 }
 
-func (s *Spawner) PollNewEntities() []Entity {
-	entities := []Entity{}
-
-	if s.counter%ASTEROID_SPAWN_INTERVAL == 0 {
-		asteroid, err := s.spawnRandomAsteroid()
-		if err == nil {
-			entities = append(entities, asteroid)
-		}
-	}
-	if s.counter%POWERUP_SPAWN_INTERVAL == 0 {
-		powerup, err := s.spawnPowerup()
-		if err == nil {
-			entities = append(entities, powerup)
-		}
-	}
+// samplePosition returns a random position in the world.
+func (s *Spawner) samplePosition() geometry.Vector {
+	return *geometry.NewRandomVector(
+		s.random,
+		0,
+		0,
+		s.worldSize,
+		s.worldSize,
+	)
+}
 
-	s.counter = (s.counter + 1) % RESET_INTERVAL
-	return entities
+// sampleGridPosition returns a random position in the world which is snapped
+// to the grid.
+func (s *Spawner) sampleGridPosition() geometry.Vector {
+	position := s.samplePosition()
+	position.X = math.Round(position.X/GRID_SIZE) * GRID_SIZE
+	position.Y = math.Round(position.Y/GRID_SIZE) * GRID_SIZE
+	return position
 }
@@ -0,0 +1,32 @@
+package entities
+
+const (
+	PLAYER_MAX_HEAT = 100
+	HEAT_COOLING    = 1 // heat lost per tick
+)
+
+// A weapon decides how often a player can fire, and how much heat each shot
+// builds up.
+type weapon struct {
+	fireInterval uint32 // min ticks between shots
+	heat         uint32 // heat per shot
+}
+
+var basicWeapon = weapon{fireInterval: 12, heat: 10}
+var multishotWeapon = weapon{fireInterval: 15, heat: 20}
+var wideBeamWeapon = weapon{fireInterval: 20, heat: 25}
+var piercingWeapon = weapon{fireInterval: 24, heat: 30}
+
+// chooseWeapon returns the weapon for the active abilities in flags.
+func chooseWeapon(flags AbilityFlag) weapon {
+	switch {
+	case isAbilityActive(flags, PiercingAbilityFlag):
+		return piercingWeapon
+	case isAbilityActive(flags, WideBeamAbilityFlag):
+		return wideBeamWeapon
+	case isAbilityActive(flags, MultishotAbilityFlag):
+		return multishotWeapon
+	default:
+		return basicWeapon
+	}
+}
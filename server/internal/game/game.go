@@ -1,12 +1,17 @@
 package game
 
 import (
+	"cmp"
 	"context"
 	"log"
+	"maps"
+	"math/rand"
 	"server/internal/game/collision"
 	"server/internal/game/constants"
 	"server/internal/game/entities"
+	"server/internal/game/geometry"
 	"server/pb"
+	"slices"
 	"sync"
 	"time"
 
@@ -14,94 +19,295 @@ import (
 )
 
 const (
-	MAX_ENTITY_COUNT = 256
+	MAX_BUFFERED_INPUTS = 16
+	FULL_DELTA_INTERVAL = 2 * constants.FPS
 )
 
+// A Message is a serialized event addressed to the client with ClientId. If
+// ClientId is empty, the message is addressed to every client in the room.
+type Message struct {
+	ClientId string
+	Data     []byte
+}
+
 // A Game stores the game's state and handles its logic.
 type Game struct {
-	Incoming chan []byte
-	Outgoing chan []byte
+	Incoming chan Command
+	Outgoing chan Message
+	config   Config
+	done     <-chan struct{} // closed once the game stops running
 	mu       sync.Mutex
 
-	// Game tate.
+	// Simulation.
+	seed     uint32
+	random   *rand.Rand // the only source of randomness in the game
+	clock    Clock
+	recorder *Recorder // or nil if the game is not recorded
+
+	// Game state.
 	entities  map[string]entities.Entity
-	usernames map[string]string
+	usernames map[string]string // usernames of humans and bots
+	bots      map[string]*bot
+	teams     map[string]uint32 // team of each player, assigned by the mode
+	round     *round
 	spawner   entities.Spawner
+	asteroids *budget            // target number of asteroids
+	powerups  *budget            // target number of powerups
+	index     *collision.Index   // spatial index from the latest tick
+	history   *collision.History // bounding boxes from recent ticks
+	tick      uint32             // frames since the game started
+	zoneSize  float64            // size of the safe zone, or 0 if there is none
+	outside   map[string]uint32  // ticks each player has spent outside the zone
+
+	// Client inputs.
+	inputs    map[string][]*pb.Event_InputEventData // buffered until next tick
+	sequences map[string]uint32                     // last processed sequence
 
 	// Game state deltas.
-	updated map[string]entities.Entity
-	removed []string
+	updated    map[string]entities.Entity
+	removed    []string
+	views      map[string]*view  // what each client can see
+	spectators map[string]string // mapping of spectator to followed player
+	outbox     []Message         // messages produced during the current tick
 }
 
-func NewGame() *Game {
-	return &Game{
-		Incoming:  make(chan []byte),
-		Outgoing:  make(chan []byte),
+// NewGame creates a game which draws all of its randomness from a source
+// seeded with seed and tells the time with clock. Games with the same seed
+// which are given the same commands evolve identically. If recorder is not
+// nil, the game's events are recorded to it.
+func NewGame(config Config, seed uint32, clock Clock, recorder *Recorder) *Game {
+	random := rand.New(rand.NewSource(int64(seed)))
+	teams := make(map[string]uint32)
+	g := &Game{
+		Incoming:  make(chan Command),
+		Outgoing:  make(chan Message),
+		config:    config,
 		mu:        sync.Mutex{},
+		seed:      seed,
+		random:    random,
+		clock:     clock,
+		recorder:  recorder,
 		entities:  make(map[string]entities.Entity),
 		usernames: map[string]string{},
-		spawner:   entities.NewSpawner(),
-		updated:   make(map[string]entities.Entity),
-		removed:   []string{},
+		bots:      make(map[string]*bot),
+		teams:     teams,
+		round:     newRound(1, 0, teams),
+		spawner:   entities.NewSpawner(random, config.WorldSize),
+		asteroids: newBudget(
+			ASTEROIDS_PER_PLAYER,
+			MIN_ASTEROID_BUDGET,
+			MAX_ASTEROID_BUDGET,
+			ASTEROID_SPAWN_INTERVAL,
+		),
+		powerups: newBudget(
+			POWERUPS_PER_PLAYER,
+			MIN_POWERUP_BUDGET,
+			MAX_POWERUP_BUDGET,
+			POWERUP_SPAWN_INTERVAL,
+		),
+		history:    collision.NewHistory(config.RewindWindow + 1),
+		tick:       0,
+		zoneSize:   0,
+		outside:    make(map[string]uint32),
+		inputs:     make(map[string][]*pb.Event_InputEventData),
+		sequences:  make(map[string]uint32),
+		updated:    make(map[string]entities.Entity),
+		removed:    []string{},
+		views:      make(map[string]*view),
+		spectators: make(map[string]string),
+		outbox:     []Message{},
 	}
+	g.init()
+	return g
 }
 
-// AddPlayer spawns a new Player into the game.
+// init spawns the initial entities and bots, and records the initial state.
+func (g *Game) init() {
+	for range entities.INITIAL_ASTEROID_COUNT {
+		if asteroid, err := g.spawner.SpawnAsteroid(g.isSafeSpawn); err == nil {
+			g.add(asteroid)
+		}
+	}
+	for range entities.INITIAL_POWERUP_COUNT {
+		if powerup, err := g.spawner.SpawnPowerup(g.isSafeSpawn); err == nil {
+			g.add(powerup)
+		}
+	}
+
+	snapshot := g.getFullSnapshot()
+	snapshot.GetSnapshotEventData().Seed = g.seed
+	snapshot.GetSnapshotEventData().Config = g.config.ToPb()
+	g.record(snapshot)
+	g.balanceBots()
+}
+
+// AddPlayer spawns a new Player into the game for a client. A bot is dropped
+// to make room for the client if needed.
 func (g *Game) AddPlayer(id string, username string) error {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	player, err := g.spawner.SpawnPlayer(id, username)
+	err := g.addPlayer(id, username)
 	if err != nil {
 		return err
 	}
 
+	g.views[id] = newView(id, g.entities[id].GetPosition())
+	g.balanceBots()
+	g.sendRound(id)
+	return nil
+}
+
+// addPlayer spawns a new Player with username into the game.
+func (g *Game) addPlayer(id string, username string) error {
+	team := g.assignTeam(id)
+	player, err := g.spawner.SpawnPlayer(id, username, g.isSafeSpawn)
+	if err != nil {
+		delete(g.teams, id)
+		return err
+	}
+	player.SetTeam(team)
+
 	g.entities[id] = player
 	g.usernames[id] = username
+	_, isBot := g.bots[id]
+	g.record(&pb.Event{
+		Type: pb.EventType_EVENT_TYPE_JOIN,
+		Data: &pb.Event_JoinEventData_{
+			JoinEventData: &pb.Event_JoinEventData{
+				Id:       id,
+				Username: username,
+				IsBot:    isBot,
+			},
+		},
+	})
 	return nil
 }
 
-// RemovePlayer removes a Player from the game.
+// RemovePlayer removes a client's Player from the game. A bot takes its place
+// if needed.
 func (g *Game) RemovePlayer(id string) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	g.removePlayer(id)
+	g.balanceBots()
+}
+
+// removePlayer removes a Player from the game.
+func (g *Game) removePlayer(id string) {
+	_, isBot := g.bots[id]
 	g.removed = append(g.removed, id)
 	delete(g.usernames, id)
+	delete(g.teams, id)
+	delete(g.views, id)
+	delete(g.inputs, id)
+	delete(g.sequences, id)
+	delete(g.outside, id)
+	g.record(&pb.Event{
+		Type: pb.EventType_EVENT_TYPE_QUIT,
+		Data: &pb.Event_QuitEventData_{
+			QuitEventData: &pb.Event_QuitEventData{
+				Id:    id,
+				IsBot: isBot,
+			},
+		},
+	})
 }
 
-// respawnPlayer adds a new Player into the game, checking if id is already
-// being used and looks up a username based on id.
-func (g *Game) respawnPlayer(id string) {
+// AddSpectator adds a client which can see the game without a Player. The
+// spectator starts at the center of the world until it follows a player.
+func (g *Game) AddSpectator(id string) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	center := geometry.Vector{X: g.config.WorldSize / 2, Y: g.config.WorldSize / 2}
+	g.views[id] = newView(id, center)
+	g.spectators[id] = ""
+	g.sendRound(id)
+}
+
+// RemoveSpectator removes a spectator from the game.
+func (g *Game) RemoveSpectator(id string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.views, id)
+	delete(g.spectators, id)
+}
+
+// ResumeClient prepares the game for a client which reconnected. The client
+// starts from a fresh snapshot, so its view is reset to send every visible
+// entity in full, and its input sequence starts again from zero.
+func (g *Game) ResumeClient(id string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	v, found := g.views[id]
+	if !found {
+		return
+	}
+
+	v.known = make(map[string]entityState)
+	delete(g.inputs, id)
+	delete(g.sequences, id)
+	g.sendRound(id)
+	g.record(&pb.Event{
+		Type: pb.EventType_EVENT_TYPE_RESUME,
+		Data: &pb.Event_ResumeEventData_{
+			ResumeEventData: &pb.Event_ResumeEventData{
+				Id: id,
+			},
+		},
+	})
+}
+
+// respawnPlayer adds a new Player into the game for the client with id. The
+// request is recorded whether or not it succeeds, since finding a spawn point
+// draws from the game's randomness either way.
+func (g *Game) respawnPlayer(id string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.record(&pb.Event{
+		Type: pb.EventType_EVENT_TYPE_RESPAWN,
+		Data: &pb.Event_RespawnEventData_{
+			RespawnEventData: &pb.Event_RespawnEventData{
+				Id: id,
+			},
+		},
+	})
+	return g.spawnPlayer(id)
+}
+
+// spawnPlayer adds a new Player for id, if it does not already have one. It
+// returns an error if there is no safe place to spawn the player.
+func (g *Game) spawnPlayer(id string) error {
 	_, found := g.entities[id]
 	if found {
-		return
+		return nil
 	}
 
 	username, found := g.usernames[id]
 	if !found {
-		return
+		return nil
 	}
 
-	player, err := g.spawner.SpawnPlayer(id, username)
+	player, err := g.spawner.SpawnPlayer(id, username, g.isSafeSpawn)
 	if err != nil {
-		// TODO: handle error
-		log.Fatalf("could not spawn player")
+		return err
 	}
+	player.SetTeam(g.teams[id])
 	g.entities[id] = player
+	return nil
 }
 
 // GetPbEntities unwraps the game's entities into their underlying EntityData
 // for serialization.
 func (g *Game) GetPbEntities() []*pb.EntityData {
 	entities := make([]*pb.EntityData, len(g.entities))
-	i := 0
-	for _, entity := range g.entities {
-		entities[i] = entity.GetEntityData()
-		i++
+	for i, id := range sortedIds(g.entities) {
+		entities[i] = g.entities[id].GetEntityData()
 	}
 	return entities
 }
@@ -109,45 +315,47 @@ func (g *Game) GetPbEntities() []*pb.EntityData {
 // GetTimestamp returns the timestamp as a float. The cast is necessary because
 // JavaScript's Number.MAX_SAFE_INTEGER can't handle int64.
 func (g *Game) GetTimestamp() float64 {
-	return float64(time.Now().UnixMilli())
+	return float64(g.clock.Now().UnixMilli())
 }
 
-// GetSnapshot serializes the game state.
-func (g *Game) GetSnapshot() *pb.Event {
+// GetSnapshot serializes the game state as seen by the client with id. If the
+// client has no view, the entire game state is serialized.
+func (g *Game) GetSnapshot(id string) *pb.Event {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	v, found := g.views[id]
+	if found {
+		return g.getViewSnapshot(v)
+	}
+	return g.getFullSnapshot()
+}
+
+// getFullSnapshot serializes the entire game state.
+func (g *Game) getFullSnapshot() *pb.Event {
 	return &pb.Event{
 		Type: pb.EventType_EVENT_TYPE_SNAPSHOT,
 		Data: &pb.Event_SnapshotEventData_{
 			SnapshotEventData: &pb.Event_SnapshotEventData{
 				Timestamp: g.GetTimestamp(),
 				Entities:  g.GetPbEntities(),
+				WorldSize: g.config.WorldSize,
+				Boundary:  g.config.Boundary.GetType(),
+				ZoneSize:  g.zoneSize,
 			},
 		},
 	}
 }
 
-// GetDelta serializes the changes in the game state between each call to
-// GetDelta.
-func (g *Game) GetDelta() *pb.Event {
-	return &pb.Event{
-		Type: pb.EventType_EVENT_TYPE_DELTA,
-		Data: &pb.Event_DeltaEventData_{
-			DeltaEventData: &pb.Event_DeltaEventData{
-				Timestamp: g.GetTimestamp(),
-				Updated:   g.GetPbEntities(),
-				Removed:   g.removed,
-			},
-		},
-	}
-}
-
-// Run starts the game loop.
+// Run starts the game loop, which steps the game once per frame and delivers
+// its messages through Outgoing.
 func (g *Game) Run(ctx context.Context) {
-	ticker := time.NewTicker(time.Second / constants.FPS)
+	ticker := time.NewTicker(constants.FRAME_DURATION)
 	defer ticker.Stop()
 
-	for _, entity := range g.spawner.InitEntities() {
-		g.entities[entity.GetId()] = entity
-		g.updated[entity.GetId()] = entity
+	g.done = ctx.Done()
+	if g.recorder != nil {
+		defer g.stopRecording()
 	}
 
 	for {
@@ -156,85 +364,197 @@ func (g *Game) Run(ctx context.Context) {
 			return
 
 		case <-ticker.C:
-			g.update()
+			g.deliver(g.Step())
 
-		case message := <-g.Incoming:
-			g.Outgoing <- message
+		case command := <-g.Incoming:
+			g.HandleCommand(command)
+		}
+	}
+}
 
-			var event pb.Event
-			proto.Unmarshal(message, &event)
+// Step advances the game by exactly one tick, and returns the messages which
+// were produced for clients during the tick. Stepping does not depend on Run,
+// so games can be simulated without goroutines or waiting on real time.
+func (g *Game) Step() []Message {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 
-			switch event.Type {
-			case pb.EventType_EVENT_TYPE_RESPAWN:
-				data := event.GetRespawnEventData()
-				g.respawnPlayer(data.GetId())
+	g.update()
+	messages := g.outbox
+	g.outbox = []Message{}
+	return messages
+}
 
-			case pb.EventType_EVENT_TYPE_INPUT:
-				data := event.GetInputEventData()
-				g.input(data)
-			}
+// HandleCommand passes a client's command to the corresponding handler.
+// Commands with event types that clients are not allowed to send are dropped.
+// Commands are always applied to the client that sent them, regardless of any
+// IDs in the event data.
+func (g *Game) HandleCommand(command Command) {
+	event := command.Event
+	if !IsClientEventType(event.GetType()) {
+		log.Printf("dropped %v from %s", event.GetType(), command.ClientId)
+		return
+	}
+
+	switch event.GetType() {
+	case pb.EventType_EVENT_TYPE_RESPAWN:
+		err := g.respawnPlayer(command.ClientId)
+		if err != nil {
+			log.Printf("failed to respawn %s: %v", command.ClientId, err)
 		}
+
+	case pb.EventType_EVENT_TYPE_INPUT:
+		data := event.GetInputEventData()
+		g.queueInput(command.ClientId, data)
+
+	case pb.EventType_EVENT_TYPE_SPECTATE:
+		data := event.GetSpectateEventData()
+		g.follow(command.ClientId, data.GetTargetId())
 	}
 }
 
-// input passes input event data to the corresponding Player.
-func (g *Game) input(data *pb.Event_InputEventData) {
-	entity, found := g.entities[data.GetId()]
-	if !found {
+// follow centers the view of the spectator with id on the player with
+// targetId. Clients which are not spectating cannot follow other players.
+func (g *Game) follow(id string, targetId string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, isSpectator := g.spectators[id]; !isSpectator {
 		return
 	}
+	if _, isPlayer := g.usernames[targetId]; !isPlayer {
+		return
+	}
+	g.spectators[id] = targetId
+}
+
+// queueInput buffers input event data from the client with id until the next
+// tick. If too many inputs are buffered for a client, the oldest input is
+// dropped.
+func (g *Game) queueInput(id string, data *pb.Event_InputEventData) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 
-	if player, ok := entity.(*entities.Player); ok {
-		player.Input(data.GetMouseX(), data.GetMouseY(), data.MousePressed)
+	if _, found := g.usernames[id]; !found {
+		return
 	}
+
+	inputs := append(g.inputs[id], data)
+	if len(inputs) > MAX_BUFFERED_INPUTS {
+		inputs = inputs[1:]
+	}
+	g.inputs[id] = inputs
+}
+
+// input passes each client's buffered inputs to the corresponding Player in
+// sequence order. Inputs which are older than the last processed input are
+// discarded.
+func (g *Game) input() {
+	for _, id := range sortedIds(g.inputs) {
+		inputs := g.inputs[id]
+		slices.SortFunc(inputs, func(a, b *pb.Event_InputEventData) int {
+			return cmp.Compare(a.GetSequence(), b.GetSequence())
+		})
+
+		player, isPlayer := g.entities[id].(*entities.Player)
+		for _, data := range inputs {
+			if data.GetSequence() <= g.sequences[id] {
+				continue
+			}
+			g.sequences[id] = data.GetSequence()
+			if _, isBot := g.bots[id]; !isBot {
+				g.recordInput(id, data)
+			}
+
+			if isPlayer {
+				player.Input(
+					data.GetMouseX(),
+					data.GetMouseY(),
+					data.GetMousePressed(),
+					data.GetTick(),
+				)
+			}
+		}
+	}
+	clear(g.inputs)
 }
 
 // update is called once per tick and computes all updates.
 //
 // More specifically, it
+//   - queues inputs for bots
+//   - applies buffered inputs
+//   - steers homing projectiles
 //   - updates positions
+//   - keeps entities within the world
 //   - resolves collisions
-//   - adds new entities
+//   - shrinks the safe zone
+//   - adds new entities, and culls asteroids over budget
 //   - removes expired entities
-//   - broacasts the updated delta
+//   - ends and starts rounds
+//   - sends each client the updated delta for its view
+//   - records the state for lag compensation
+//
+// Entities are visited in ID order, so that the same game state always
+// produces the same next state.
 func (g *Game) update() {
-	g.mu.Lock()
-	defer g.mu.Unlock()
-
+	g.steerBots()
+	g.input()
+	g.steerProjectiles()
 	g.updateEntities()
 	g.resolveCollisions()
+	g.updateZone()
 	g.pollNewEntities()
 	for _, id := range g.removed {
 		delete(g.entities, id)
 		delete(g.updated, id)
 	}
+	g.updateRound()
 
-	data := g.GetDelta()
-	g.broadcast(data)
+	g.sendDeltas()
+	if g.recorder != nil {
+		g.record(g.getViewDelta(g.recorder.view, false))
+	}
+	g.history.Record(g.tick, &g.entities)
 
 	clear(g.updated)
 	g.removed = g.removed[:0]
+	g.tick++
 }
 
-// updateEntities updates entities, and marks expired entities for deletion.
+// updateEntities updates entities and keeps them within the world, and marks
+// expired entities for deletion.
 func (g *Game) updateEntities() {
-	for id, entity := range g.entities {
+	for _, id := range sortedIds(g.entities) {
+		entity := g.entities[id]
 		if entity.Update() {
 			g.updated[id] = entity
 		}
+		g.bound(entity)
 		if entity.GetIsExpired() {
 			g.removed = append(g.removed, id)
 		}
 	}
 }
 
-// resolveCollisions checks and handles collisions for all entities.
+// resolveCollisions checks and handles collisions for all entities. In a
+// wrapped world, entities also collide across its edges.
 func (g *Game) resolveCollisions() {
-	collision.ResolveCollisionsLineSweep(&g.entities, g.handleCollision)
+	if g.isWrapped() {
+		g.index = collision.ResolveCollisionsWrapped(
+			&g.entities,
+			g.config.WorldSize,
+			g.handleCollision,
+		)
+		return
+	}
+	g.index = collision.ResolveCollisionsLineSweep(&g.entities, g.handleCollision)
 }
 
 // handleCollision updates the entities with id1 and id2 and marks them for
-// removal if needed.
+// removal if needed. Collisions between players which cannot damage each
+// other under the game mode are ignored, as are repeated hits by piercing
+// projectiles, and kills are credited to the round.
 func (g *Game) handleCollision(id1 *string, id2 *string) {
 	if id1 == id2 {
 		return
@@ -242,45 +562,184 @@ func (g *Game) handleCollision(id1 *string, id2 *string) {
 
 	e1 := g.entities[*id1]
 	e2 := g.entities[*id2]
+	if !g.canDamage(e1, e2) || hasHit(e1, e2) || hasHit(e2, e1) {
+		return
+	}
 	e1.UpdateOnCollision(e2)
 	e2.UpdateOnCollision(e1)
 
 	if e1.RemoveOnCollision(e2) {
-		g.removed = append(g.removed, e1.GetId())
+		g.remove(e1)
 	}
 	if e2.RemoveOnCollision(e1) {
-		g.removed = append(g.removed, e2.GetId())
+		g.remove(e2)
+	}
+}
+
+// remove marks entity for removal. A death is only recorded the first time a
+// player is removed.
+func (g *Game) remove(entity entities.Entity) {
+	if slices.Contains(g.removed, entity.GetId()) {
+		return
+	}
+	g.removed = append(g.removed, entity.GetId())
+	g.recordDeath(entity)
+}
+
+// compensateLag checks a newly fired projectile for hits against the game as
+// its owner saw it. The projectile is stepped forward from the tick seen by
+// the owner up to the current tick, and checked for collisions against the
+// recorded state at each step. The rewind is capped at the rewind window.
+func (g *Game) compensateLag(projectile *entities.Projectile) {
+	start := min(projectile.GetTick(), g.tick)
+	if g.tick-start > uint32(g.config.RewindWindow) {
+		start = g.tick - uint32(g.config.RewindWindow)
+	}
+
+	id := projectile.GetId()
+	for tick := start; tick < g.tick; tick++ {
+		for _, otherId := range g.findCollisions(tick, projectile.GetBoundingBox()) {
+			if otherId == projectile.GetOwnerId() || slices.Contains(g.removed, otherId) {
+				continue
+			}
+			if _, found := g.entities[otherId]; !found {
+				continue
+			}
+
+			g.handleCollision(&id, &otherId)
+
+			// Entities destroyed by the projectile may already have been
+			// polled this tick (e.g. fragments of an asteroid).
+			if slices.Contains(g.removed, otherId) {
+				g.pollEntity(g.entities[otherId])
+			}
+			if slices.Contains(g.removed, id) {
+				return
+			}
+		}
+		projectile.Update()
+		g.bound(projectile)
+		if slices.Contains(g.removed, id) {
+			return
+		}
 	}
 }
 
 // pollNewEntities polls all new entities that have been created and adds them
-// into the game.
+// into the game, then spawns entities within their budgets.
 func (g *Game) pollNewEntities() {
-	for _, entity := range g.entities {
-		for _, newEntity := range entity.PollNewEntities() {
-			g.entities[newEntity.GetId()] = newEntity
-			g.updated[newEntity.GetId()] = newEntity
-		}
+	for _, id := range sortedIds(g.entities) {
+		g.pollEntity(g.entities[id])
 	}
+	g.spawnEntities()
+}
 
-	if len(g.entities) > MAX_ENTITY_COUNT {
-		return
+// pollEntity adds the entities created by entity into the game. New
+// projectiles are dropped if their owner has too many in flight, and are
+// otherwise checked for hits with lag compensation.
+func (g *Game) pollEntity(entity entities.Entity) {
+	for _, newEntity := range entity.PollNewEntities() {
+		projectile, isProjectile := newEntity.(*entities.Projectile)
+		if isProjectile && !g.canFire(projectile.GetOwnerId()) {
+			continue
+		}
+
+		g.add(newEntity)
+		if isProjectile {
+			g.compensateLag(projectile)
+		}
 	}
-	for _, newEntity := range g.spawner.PollNewEntities() {
-		g.entities[newEntity.GetId()] = newEntity
-		g.updated[newEntity.GetId()] = newEntity
+}
+
+// sendDeltas sends each client the delta for its own view. Every
+// FULL_DELTA_INTERVAL frames, all visible entities are sent in full so that
+// clients can recover from any missed deltas.
+func (g *Game) sendDeltas() {
+	isFull := g.tick%FULL_DELTA_INTERVAL == 0
+	for _, id := range sortedIds(g.views) {
+		v := g.views[id]
+		target := id
+		if followed, isSpectator := g.spectators[id]; isSpectator {
+			target = followed
+		}
+		if player, found := g.entities[target]; found {
+			v.center = player.GetPosition()
+		}
+
+		err := g.queue(id, g.getViewDelta(v, isFull))
+		if err != nil {
+			log.Printf("failed to send delta to %s: %v", id, err)
+		}
 	}
 }
 
-// broadcast sends a message to the room.
-//
-// TODO: maybe this shouldn't be here
-func (g *Game) broadcast(data *pb.Event) error {
+// queue adds a message for the client with clientId to the current tick's
+// outbox.
+func (g *Game) queue(clientId string, data *pb.Event) error {
 	message, err := proto.Marshal(data)
 	if err != nil {
 		return err
 	}
 
-	g.Outgoing <- message
+	g.outbox = append(g.outbox, Message{ClientId: clientId, Data: message})
 	return nil
 }
+
+// deliver sends messages through Outgoing. Messages are dropped if the game
+// has stopped.
+func (g *Game) deliver(messages []Message) {
+	for _, message := range messages {
+		select {
+		case <-g.done:
+			return
+		case g.Outgoing <- message:
+		}
+	}
+}
+
+// sortedIds returns the keys of m in sorted order, so that maps can be
+// iterated deterministically.
+func sortedIds[T any](m map[string]T) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// record appends event to the game's recording, if it is being recorded.
+func (g *Game) record(event *pb.Event) {
+	if g.recorder == nil {
+		return
+	}
+
+	err := g.recorder.Record(event)
+	if err != nil {
+		log.Printf("failed to record %v: %v", event.GetType(), err)
+	}
+}
+
+// recordInput records an input from the client with id as it was applied.
+func (g *Game) recordInput(id string, data *pb.Event_InputEventData) {
+	g.record(&pb.Event{
+		Type: pb.EventType_EVENT_TYPE_INPUT,
+		Data: &pb.Event_InputEventData_{
+			InputEventData: &pb.Event_InputEventData{
+				Id:           id,
+				MouseX:       data.GetMouseX(),
+				MouseY:       data.GetMouseY(),
+				MousePressed: data.GetMousePressed(),
+				Sequence:     data.GetSequence(),
+				Tick:         data.GetTick(),
+			},
+		},
+	})
+}
+
+// stopRecording flushes and closes the game's recording.
+func (g *Game) stopRecording() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	err := g.recorder.Close()
+	if err != nil {
+		log.Printf("failed to close recording: %v", err)
+	}
+	g.recorder = nil
+}
@@ -0,0 +1,449 @@
+package game
+
+import (
+	"fmt"
+	"path/filepath"
+	"server/internal/game/constants"
+	"server/internal/game/entities"
+	"server/internal/game/geometry"
+	"server/pb"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// simulate steps a new game with seed for ticks, with a single player which
+// turns in circles and fires every second.
+func simulate(seed uint32, ticks int) []*pb.EntityData {
+	clock := NewManualClock(time.UnixMilli(0))
+	g := NewGame(NewConfig(DEFAULT_REWIND_WINDOW, 0), seed, clock, nil)
+	g.AddPlayer("player", "player")
+
+	for i := range ticks {
+		g.HandleCommand(Command{
+			ClientId: "player",
+			Event: &pb.Event{
+				Type: pb.EventType_EVENT_TYPE_INPUT,
+				Data: &pb.Event_InputEventData_{
+					InputEventData: &pb.Event_InputEventData{
+						Id:           "player",
+						MouseX:       float64(i%120) / 120,
+						MouseY:       0.5,
+						MousePressed: i%constants.FPS == 0,
+						Sequence:     uint32(i + 1),
+						Tick:         uint32(i),
+					},
+				},
+			},
+		})
+		g.Step()
+		clock.Advance(constants.FRAME_DURATION)
+	}
+	return g.GetPbEntities()
+}
+
+func TestStep(t *testing.T) {
+	tests := map[string]struct {
+		seed1 uint32
+		seed2 uint32
+		want  bool
+	}{
+		"same seed":      {1, 1, true},
+		"different seed": {1, 2, false},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			e1 := simulate(test.seed1, 5*constants.FPS)
+			e2 := simulate(test.seed2, 5*constants.FPS)
+			got := slices.EqualFunc(e1, e2, func(a, b *pb.EntityData) bool {
+				return proto.Equal(a, b)
+			})
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestBalanceBots(t *testing.T) {
+	tests := map[string]struct {
+		botCount int
+		humans   int
+		want     int
+	}{
+		"no bots":                     {0, 2, 0},
+		"bots fill empty game":        {4, 0, 4},
+		"bots dropped as humans join": {4, 3, 1},
+		"no bots when full":           {4, 5, 0},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			clock := NewManualClock(time.UnixMilli(0))
+			g := NewGame(NewConfig(DEFAULT_REWIND_WINDOW, test.botCount), 1, clock, nil)
+			for i := range test.humans {
+				g.AddPlayer(fmt.Sprintf("%d", i), "player")
+			}
+			g.Step()
+
+			got := len(g.bots)
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRecordDeath(t *testing.T) {
+	tests := map[string]struct {
+		killerId string
+		want     uint32
+	}{
+		"killed by player":   {"a", 1},
+		"killed by nobody":   {"", 0},
+		"killed by themself": {"b", 0},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			clock := NewManualClock(time.UnixMilli(0))
+			g := NewGame(NewConfig(DEFAULT_REWIND_WINDOW, 0), 1, clock, nil)
+			g.AddPlayer("a", "a")
+			g.AddPlayer("b", "b")
+			for range entities.PLAYER_SPAWN_INVULNERABILITY {
+				g.Step()
+			}
+
+			victim, found := g.entities["b"].(*entities.Player)
+			if !found {
+				t.Fatalf("want player b but got none")
+			}
+			victim.Damage(entities.PLAYER_MAX_HEALTH, pb.DamageCause_DAMAGE_CAUSE_PROJECTILE, test.killerId)
+			g.remove(victim)
+
+			got := g.round.kills["a"]
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestFireRate(t *testing.T) {
+	tests := map[string]struct {
+		interval int // ticks between inputs which fire
+		want     int
+	}{
+		"fire every tick":        {1, 5},
+		"fire every half second": {constants.FPS / 2, 2},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			clock := NewManualClock(time.UnixMilli(0))
+			g := NewGame(NewConfig(DEFAULT_REWIND_WINDOW, 0), 1, clock, nil)
+			g.AddPlayer("player", "player")
+
+			// Projectiles may be destroyed soon after they are fired, so
+			// each one is counted when it is first seen.
+			fired := make(map[string]bool)
+			for i := range constants.FPS {
+				g.queueInput("player", &pb.Event_InputEventData{
+					Id:           "player",
+					MouseX:       1,
+					MouseY:       0,
+					MousePressed: i%test.interval == 0,
+					Sequence:     uint32(i + 1),
+					Tick:         uint32(i),
+				})
+				g.Step()
+
+				for id, entity := range g.entities {
+					projectile, ok := entity.(*entities.Projectile)
+					if ok && projectile.GetOwnerId() == "player" {
+						fired[id] = true
+					}
+				}
+			}
+
+			got := len(fired)
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestSelfDamage(t *testing.T) {
+	tests := map[string]struct {
+		ability entities.AbilityFlag
+		want    uint32
+	}{
+		"no ability":  {0, entities.PLAYER_MAX_HEALTH},
+		"piercing":    {entities.PiercingAbilityFlag, entities.PLAYER_MAX_HEALTH},
+		"speed boost": {entities.SpeedBoostAbilityFlag, entities.PLAYER_MAX_HEALTH},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			clock := NewManualClock(time.UnixMilli(0))
+			g := NewGame(NewConfig(DEFAULT_REWIND_WINDOW, 0), 1, clock, nil)
+			g.AddPlayer("player", "player")
+			for id := range g.entities {
+				if id != "player" {
+					delete(g.entities, id)
+				}
+			}
+
+			player := g.entities["player"].(*entities.Player)
+			if test.ability != 0 {
+				powerup, err := g.spawner.SpawnPowerup(g.isSafeSpawn)
+				if err != nil {
+					t.Fatalf("want powerup but got %v", err)
+				}
+				powerup.GetEntityData().GetPowerupData().Ability = uint32(test.ability)
+				player.UpdateOnCollision(powerup)
+			}
+
+			// Damage is ignored while the player is invulnerable, so the
+			// player keeps firing afterwards.
+			for i := range entities.PLAYER_SPAWN_INVULNERABILITY + 2*constants.FPS {
+				g.queueInput("player", &pb.Event_InputEventData{
+					Id:           "player",
+					MouseX:       1,
+					MouseY:       0,
+					MousePressed: true,
+					Sequence:     uint32(i + 1),
+					Tick:         uint32(i),
+				})
+				g.Step()
+			}
+
+			got := player.GetHealth()
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := map[string]struct {
+		seed uint32
+	}{
+		"seed 1": {1},
+		"seed 2": {2},
+		"seed 3": {3},
+		"seed 4": {4},
+		"seed 5": {5},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			clock := NewManualClock(time.UnixMilli(0))
+			g := NewGame(NewConfig(DEFAULT_REWIND_WINDOW, 0), test.seed, clock, nil)
+			g.AddPlayer("player", "player")
+			player := g.entities["player"].(*entities.Player)
+			player.Input(1, 0, true, 0)
+			projectile := player.PollNewEntities()[0]
+
+			// Only the asteroid and its fragments are left in the game, so
+			// that nothing else can destroy the fragments.
+			var asteroid entities.Entity
+			for _, id := range sortedIds(g.entities) {
+				entity := g.entities[id]
+				if asteroid == nil && entity.GetEntityType() == pb.EntityType_ENTITY_TYPE_ASTEROID {
+					asteroid = entity
+				} else {
+					delete(g.entities, id)
+				}
+			}
+			for range entities.ASTEROID_MAX_HEALTH {
+				asteroid.RemoveOnCollision(projectile)
+			}
+			g.remove(asteroid)
+			g.pollEntity(asteroid)
+			g.Step()
+
+			fragments := 0
+			for _, entity := range g.entities {
+				if entity.GetEntityType() == pb.EntityType_ENTITY_TYPE_ASTEROID {
+					fragments++
+				}
+			}
+			if fragments < entities.ASTEROID_MIN_FRAGMENTS {
+				t.Errorf("want at least %v fragments but got %v", entities.ASTEROID_MIN_FRAGMENTS, fragments)
+			}
+		})
+	}
+}
+
+func TestAddSpectator(t *testing.T) {
+	tests := map[string]struct {
+		botCount int
+		humans   int
+		want     int
+	}{
+		"spectator in an empty game": {4, 0, 4},
+		"spectator beside humans":    {4, 3, 1},
+		"spectator without bots":     {0, 1, 0},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			clock := NewManualClock(time.UnixMilli(0))
+			g := NewGame(NewConfig(DEFAULT_REWIND_WINDOW, test.botCount), 1, clock, nil)
+			for i := range test.humans {
+				g.AddPlayer(fmt.Sprintf("%d", i), "player")
+			}
+			g.AddSpectator("spectator")
+			g.Step()
+
+			got := len(g.bots)
+			if got != test.want {
+				t.Errorf("want %v bots but got %v", test.want, got)
+			}
+			if _, found := g.entities["spectator"]; found {
+				t.Errorf("want no player for the spectator")
+			}
+
+			center := geometry.Vector{X: g.config.WorldSize / 2, Y: g.config.WorldSize / 2}
+			if g.views["spectator"].center != center {
+				t.Errorf("want view at %v but got %v", center, g.views["spectator"].center)
+			}
+		})
+	}
+}
+
+func TestFollow(t *testing.T) {
+	tests := map[string]struct {
+		id       string
+		targetId string
+		want     string // player whose position the view is centered on, or empty for the world center
+	}{
+		"spectator follows a player":    {"spectator", "player", "player"},
+		"spectator follows an unknown":  {"spectator", "unknown", ""},
+		"spectator follows a spectator": {"spectator", "other", ""},
+		"player cannot follow":          {"player", "enemy", "player"},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			clock := NewManualClock(time.UnixMilli(0))
+			g := NewGame(NewConfig(DEFAULT_REWIND_WINDOW, 0), 1, clock, nil)
+			g.AddPlayer("player", "player")
+			g.AddPlayer("enemy", "enemy")
+			g.AddSpectator("spectator")
+			g.AddSpectator("other")
+
+			g.HandleCommand(Command{
+				ClientId: test.id,
+				Event: &pb.Event{
+					Type: pb.EventType_EVENT_TYPE_SPECTATE,
+					Data: &pb.Event_SpectateEventData_{
+						SpectateEventData: &pb.Event_SpectateEventData{TargetId: test.targetId},
+					},
+				},
+			})
+			g.Step()
+
+			if _, isSpectator := g.spectators["player"]; isSpectator {
+				t.Errorf("want player to not become a spectator")
+			}
+
+			want := geometry.Vector{X: g.config.WorldSize / 2, Y: g.config.WorldSize / 2}
+			if test.want != "" {
+				want = g.entities[test.want].GetPosition()
+			}
+			got := g.views[test.id].center
+			if got != want {
+				t.Errorf("want %v but got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestInput(t *testing.T) {
+	// One more input than can be buffered, where the first one is dropped
+	overflow := []uint32{MAX_BUFFERED_INPUTS + 1}
+	applied := []uint32{}
+	for i := range MAX_BUFFERED_INPUTS {
+		overflow = append(overflow, uint32(i+1))
+		applied = append(applied, uint32(i+1))
+	}
+
+	tests := map[string]struct {
+		ticks        [][]uint32 // sequences of the inputs queued before each tick
+		want         []uint32   // sequences of the applied inputs
+		wantSequence uint32     // last applied sequence reported in the delta
+	}{
+		"inputs in order":          {[][]uint32{{1, 2, 3}}, []uint32{1, 2, 3}, 3},
+		"inputs out of order":      {[][]uint32{{3, 1, 2}}, []uint32{1, 2, 3}, 3},
+		"duplicate input":          {[][]uint32{{1, 2, 2, 3}}, []uint32{1, 2, 3}, 3},
+		"stale inputs":             {[][]uint32{{1, 2}, {2, 1, 3}}, []uint32{1, 2, 3}, 3},
+		"only stale inputs":        {[][]uint32{{5}, {4, 3}}, []uint32{5}, 5},
+		"too many inputs buffered": {[][]uint32{overflow}, applied, MAX_BUFFERED_INPUTS},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "match.rec")
+			recorder, err := NewRecorder(path)
+			if err != nil {
+				t.Fatalf("could not create recorder: %v", err)
+			}
+			clock := NewManualClock(time.UnixMilli(0))
+			g := NewGame(NewConfig(DEFAULT_REWIND_WINDOW, 0), 1, clock, recorder)
+			g.AddPlayer("player", "player")
+
+			gotSequence := uint32(0)
+			for _, sequences := range test.ticks {
+				for _, sequence := range sequences {
+					g.HandleCommand(Command{
+						ClientId: "player",
+						Event: &pb.Event{
+							Type: pb.EventType_EVENT_TYPE_INPUT,
+							Data: &pb.Event_InputEventData_{
+								InputEventData: &pb.Event_InputEventData{
+									Id:       "player",
+									Sequence: sequence,
+								},
+							},
+						},
+					})
+				}
+
+				for _, message := range g.Step() {
+					var event pb.Event
+					proto.Unmarshal(message.Data, &event)
+					if message.ClientId == "player" && event.GetType() == pb.EventType_EVENT_TYPE_DELTA {
+						gotSequence = event.GetDeltaEventData().GetInputSequence()
+					}
+				}
+				clock.Advance(constants.FRAME_DURATION)
+			}
+			g.stopRecording()
+
+			events, err := ReadRecording(path)
+			if err != nil {
+				t.Fatalf("could not read recording: %v", err)
+			}
+			got := []uint32{}
+			for _, event := range events {
+				if event.GetType() == pb.EventType_EVENT_TYPE_INPUT {
+					got = append(got, event.GetInputEventData().GetSequence())
+				}
+			}
+
+			if !slices.Equal(got, test.want) {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+			if gotSequence != test.wantSequence {
+				t.Errorf("want sequence %v but got %v", test.wantSequence, gotSequence)
+			}
+		})
+	}
+}
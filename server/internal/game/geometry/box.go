@@ -19,6 +19,56 @@ func NewBoundingBox(position *Vector, rotation *float64, points *[]*Vector) *Bou
 	}
 }
 
+// Copy returns a BoundingBox with the current position and rotation of b.
+// Unlike b, the copy does not move along with the entity that b belongs to.
+func (b *BoundingBox) Copy() *BoundingBox {
+	position := *b.position
+	rotation := *b.rotation
+	return NewBoundingBox(&position, &rotation, b.points)
+}
+
+// GetWrappedCopies returns copies of b which are shifted to the opposite side
+// of a square world of size, for each edge of the world that b crosses. The
+// copies let b collide with entities across the edges of a wrapped world.
+func (b *BoundingBox) GetWrappedCopies(size float64) []*BoundingBox {
+	minX, maxX := b.HorizontalBounds()
+	minY, maxY := b.VerticalBounds()
+	dxs := GetWrapOffsets(minX, maxX, size)
+	dys := GetWrapOffsets(minY, maxY, size)
+
+	copies := []*BoundingBox{}
+	for _, dx := range dxs {
+		for _, dy := range dys {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+			copies = append(copies, b.Shift(dx, dy))
+		}
+	}
+	return copies
+}
+
+// Shift returns a copy of b which is moved by dx and dy.
+func (b *BoundingBox) Shift(dx float64, dy float64) *BoundingBox {
+	c := b.Copy()
+	c.position.X += dx
+	c.position.Y += dy
+	return c
+}
+
+// GetWrapOffsets returns the offsets which move the range [min, max] across
+// the edges of [0, size] that it crosses, including the zero offset.
+func GetWrapOffsets(min float64, max float64, size float64) []float64 {
+	offsets := []float64{0}
+	if min < 0 {
+		offsets = append(offsets, size)
+	}
+	if max > size {
+		offsets = append(offsets, -size)
+	}
+	return offsets
+}
+
 // DidCollide uses the Separating Axis Theorem (SAT) to determine if b1 is
 // colliding with b2.
 func (b1 *BoundingBox) DidCollide(b2 *BoundingBox) bool {
@@ -68,6 +118,19 @@ func (b *BoundingBox) HorizontalBounds() (float64, float64) {
 	return min, max
 }
 
+// VerticalBounds returns a pair of y-coordinates (in world space) which bounds
+// b.
+func (b *BoundingBox) VerticalBounds() (float64, float64) {
+	min := math.Inf(1)
+	max := math.Inf(-1)
+	for _, point := range *b.points {
+		w := b.convertToWorldSpace(point)
+		min = math.Min(w.Y, min)
+		max = math.Max(w.Y, max)
+	}
+	return min, max
+}
+
 // normals returns the normal vectors of each line segment in b.
 func (b *BoundingBox) normals() []*Vector {
 	normals := []*Vector{}
@@ -40,6 +40,60 @@ func TestDidCollide(t *testing.T) {
 	}
 }
 
+func TestCopy(t *testing.T) {
+	position := NewVector(1, 2)
+	rotation := 0.0
+	b := NewBoundingBox(position, &rotation, &square)
+	c := b.Copy()
+
+	position.X = 5
+	rotation = math.Pi / 4
+	if !c.position.IsEqual(NewVector(1, 2)) || *c.rotation != 0 {
+		t.Errorf("want copy at (1, 2) with rotation 0 but got %v with rotation %f", c.position, *c.rotation)
+	}
+	if c.DidCollide(b) {
+		t.Errorf("want copy to not collide with moved bounding box")
+	}
+}
+
+func TestGetWrappedCopies(t *testing.T) {
+	tests := map[string]struct {
+		b    *BoundingBox
+		want []*Vector
+	}{
+		"GetWrappedCopies inside the world": {
+			constructBoundingBox(5, 5, 0, square),
+			[]*Vector{},
+		},
+		"GetWrappedCopies across the left edge": {
+			constructBoundingBox(0.5, 5, 0, square),
+			[]*Vector{NewVector(10.5, 5)},
+		},
+		"GetWrappedCopies across the bottom edge": {
+			constructBoundingBox(5, 9.5, 0, square),
+			[]*Vector{NewVector(5, -0.5)},
+		},
+		"GetWrappedCopies across a corner": {
+			constructBoundingBox(9.5, 0.5, 0, square),
+			[]*Vector{NewVector(9.5, 10.5), NewVector(-0.5, 0.5), NewVector(-0.5, 10.5)},
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			got := test.b.GetWrappedCopies(10)
+			if len(got) != len(test.want) {
+				t.Fatalf("want %v but got %v", test.want, got)
+			}
+			for i, c := range got {
+				if !c.position.IsEqual(test.want[i]) {
+					t.Errorf("want %v but got %v", test.want[i], c.position)
+				}
+			}
+		})
+	}
+}
+
 func TestHorizontalBounds(t *testing.T) {
 	tests := map[string]struct {
 		b       *BoundingBox
@@ -61,6 +115,27 @@ func TestHorizontalBounds(t *testing.T) {
 	}
 }
 
+func TestVerticalBounds(t *testing.T) {
+	tests := map[string]struct {
+		b       *BoundingBox
+		wantMin float64
+		wantMax float64
+	}{
+		"VerticalBounds":                     {b1, -1, 1},
+		"VerticalBounds with rotated square": {b4, 2 - math.Sqrt2, 2 + math.Sqrt2},
+	}
+
+	for desc, test := range tests {
+		title := fmt.Sprintf("%s: %v vertical bounds", desc, test.b)
+		t.Run(title, func(t *testing.T) {
+			gotMin, gotMax := test.b.VerticalBounds()
+			if math.Abs(gotMin-test.wantMin) > EPSILON || math.Abs(gotMax-test.wantMax) > EPSILON {
+				t.Errorf("want (%f, %f) but got (%f, %f)", test.wantMin, test.wantMax, gotMin, gotMax)
+			}
+		})
+	}
+}
+
 func TestNormals(t *testing.T) {
 	tests := map[string]struct {
 		b    *BoundingBox
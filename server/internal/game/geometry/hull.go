@@ -19,23 +19,25 @@ func NewRectangleHull(
 	return points
 }
 
+// NewRandomConvexHull returns a convex hull around points drawn from random.
 func NewRandomConvexHull(
+	random *rand.Rand,
 	minNumPoints int,
 	maxNumPoints int,
 	minRadius float64,
 	maxRadius float64,
 ) []*Vector {
-	numPoints := minNumPoints + rand.Intn(maxNumPoints-minNumPoints+1)
+	numPoints := minNumPoints + random.Intn(maxNumPoints-minNumPoints+1)
 	points := make([]*Vector, numPoints)
 	for i := range numPoints {
 		points[i] = &Vector{
 			X: math.Copysign(
-				minRadius+rand.Float64()*maxRadius,
-				rand.Float64()-0.5,
+				minRadius+random.Float64()*maxRadius,
+				random.Float64()-0.5,
 			),
 			Y: math.Copysign(
-				minRadius+rand.Float64()*maxRadius,
-				rand.Float64()-0.5,
+				minRadius+random.Float64()*maxRadius,
+				random.Float64()-0.5,
 			),
 		}
 	}
@@ -87,6 +89,63 @@ func HullArea(points []*Vector) float64 {
 	return math.Abs(area) / 2.0
 }
 
+// HullCentroid returns the center of mass of the polygon formed by points. The
+// average of points is returned if the polygon has no area.
+func HullCentroid(points []*Vector) Vector {
+	centroid := Vector{X: 0, Y: 0}
+	area := 0.0
+	for i := range len(points) {
+		j := (i + 1) % len(points)
+		cross := points[i].cross(points[j])
+		area += cross
+		centroid.X += (points[i].X + points[j].X) * cross
+		centroid.Y += (points[i].Y + points[j].Y) * cross
+	}
+	if math.Abs(area) < EPSILON {
+		centroid = Vector{X: 0, Y: 0}
+		for _, point := range points {
+			centroid.X += point.X / float64(len(points))
+			centroid.Y += point.Y / float64(len(points))
+		}
+		return centroid
+	}
+
+	// The shoelace sum is twice the signed area.
+	centroid.X /= 3 * area
+	centroid.Y /= 3 * area
+	return centroid
+}
+
+// CutHull cuts the convex hull formed by points along the line through origin
+// with direction. It returns the hulls on the left and right of the line, in
+// the same winding order as points. A hull has fewer than 3 points if the line
+// does not cut through points.
+func CutHull(points []*Vector, origin *Vector, direction *Vector) ([]*Vector, []*Vector) {
+	left := []*Vector{}
+	right := []*Vector{}
+	for i := range len(points) {
+		a := points[i]
+		b := points[(i+1)%len(points)]
+		sideA := direction.cross(a.Sub(origin))
+		sideB := direction.cross(b.Sub(origin))
+
+		if sideA >= 0 {
+			left = append(left, a)
+		}
+		if sideA <= 0 {
+			right = append(right, a)
+		}
+
+		// Both hulls share the point where the edge crosses the line.
+		if (sideA > 0 && sideB < 0) || (sideA < 0 && sideB > 0) {
+			crossing := a.Add(b.Sub(a).Multiply(sideA / (sideA - sideB)))
+			left = append(left, crossing)
+			right = append(right, crossing)
+		}
+	}
+	return left, right
+}
+
 // sortPointsAbout orders points based on their angle to origin.
 func sortPointsAbout(origin *Vector, points []*Vector) {
 	slices.SortFunc(points, func(a *Vector, b *Vector) int {
@@ -87,6 +87,59 @@ func TestHullArea(t *testing.T) {
 	}
 }
 
+func TestHullCentroid(t *testing.T) {
+	tests := map[string]struct {
+		points []*Vector
+		want   Vector
+	}{
+		"HullCentroid square":   {NewRectangleHull(2, 2), Vector{X: 0, Y: 0}},
+		"HullCentroid triangle": {[]*Vector{{X: 0, Y: 0}, {X: 3, Y: 0}, {X: 0, Y: 3}}, Vector{X: 1, Y: 1}},
+		"HullCentroid line":     {[]*Vector{{X: 0, Y: 0}, {X: 2, Y: 2}}, Vector{X: 1, Y: 1}},
+	}
+
+	for desc, test := range tests {
+		title := fmt.Sprintf("%s: centroid of %v", desc, test.points)
+		t.Run(title, func(t *testing.T) {
+			got := HullCentroid(test.points)
+			if !got.IsEqual(&test.want) {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCutHull(t *testing.T) {
+	square := NewRectangleHull(2, 2)
+	tests := map[string]struct {
+		origin    *Vector
+		direction *Vector
+		wantLeft  float64
+		wantRight float64
+	}{
+		"CutHull in half":          {NewVector(0, 0), NewVector(0, 1), 2, 2},
+		"CutHull off center":       {NewVector(0.5, 0), NewVector(0, 1), 3, 1},
+		"CutHull diagonally":       {NewVector(0, 0), NewVector(1, 1), 2, 2},
+		"CutHull through a corner": {NewVector(-1, -1), NewVector(1, 1), 2, 2},
+		"CutHull missing the hull": {NewVector(5, 0), NewVector(0, 1), 4, 0},
+	}
+
+	for desc, test := range tests {
+		title := fmt.Sprintf("%s: cut along %v through %v", desc, test.direction, test.origin)
+		t.Run(title, func(t *testing.T) {
+			left, right := CutHull(square, test.origin, test.direction)
+			gotLeft := HullArea(left)
+			gotRight := HullArea(right)
+			if math.Abs(gotLeft-test.wantLeft) > EPSILON || math.Abs(gotRight-test.wantRight) > EPSILON {
+				t.Errorf(
+					"want areas %v and %v but got %v and %v",
+					test.wantLeft, test.wantRight,
+					gotLeft, gotRight,
+				)
+			}
+		})
+	}
+}
+
 func TestSortPointsAbout(t *testing.T) {
 	tests := map[string]struct {
 		origin Vector
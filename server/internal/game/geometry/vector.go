@@ -17,10 +17,18 @@ func NewVector(x float64, y float64) *Vector {
 	return &Vector{X: x, Y: y}
 }
 
-func NewRandomVector(minX float64, minY float64, maxX float64, maxY float64) *Vector {
+// NewRandomVector returns a vector drawn from random within the rectangle
+// bounded by (minX, minY) and (maxX, maxY).
+func NewRandomVector(
+	random *rand.Rand,
+	minX float64,
+	minY float64,
+	maxX float64,
+	maxY float64,
+) *Vector {
 	return &Vector{
-		X: minX + rand.Float64()*(maxX-minX),
-		Y: minY + rand.Float64()*(maxY-minY),
+		X: minX + random.Float64()*(maxX-minX),
+		Y: minY + random.Float64()*(maxY-minY),
 	}
 }
 
@@ -0,0 +1,46 @@
+package game
+
+import (
+	"server/internal/game/entities"
+	"server/internal/game/geometry"
+)
+
+// steerProjectiles turns homing projectiles towards the nearest player within
+// range which their owner can damage.
+func (g *Game) steerProjectiles() {
+	for _, id := range sortedIds(g.entities) {
+		projectile, ok := g.entities[id].(*entities.Projectile)
+		if !ok || projectile.GetFlags()&entities.HomingAbilityFlag == 0 {
+			continue
+		}
+
+		position := projectile.GetPosition()
+		var target *geometry.Vector
+		targetDistance := entities.HOMING_RANGE
+		for _, playerId := range sortedIds(g.usernames) {
+			player, found := g.entities[playerId]
+			if !found || !g.canDamage(projectile, player) {
+				continue
+			}
+
+			// Targets across the edges of a wrapped world are chased across
+			// the edges
+			other := g.getNearestCopy(position, player.GetPosition())
+			distance := other.Sub(&position).Length()
+			if distance < targetDistance {
+				target = &other
+				targetDistance = distance
+			}
+		}
+		if target != nil {
+			projectile.SteerTowards(*target)
+		}
+	}
+}
+
+// hasHit reports whether e1 is a projectile which already hit e2, so that
+// piercing projectiles only hit each entity once.
+func hasHit(e1 entities.Entity, e2 entities.Entity) bool {
+	projectile, ok := e1.(*entities.Projectile)
+	return ok && projectile.HasHit(e2.GetId())
+}
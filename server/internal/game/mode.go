@@ -0,0 +1,191 @@
+package game
+
+import (
+	"cmp"
+	"fmt"
+	"server/pb"
+)
+
+const (
+	NO_TEAM   = 0
+	TEAM_RED  = 1
+	TEAM_BLUE = 2
+
+	DEFAULT_TEAM_DEATHMATCH_SCORE_LIMIT = 30
+	DEFAULT_FIRST_TO_N_SCORE_LIMIT      = 10
+
+	MODE_FREE_FOR_ALL    = "ffa"
+	MODE_TEAM_DEATHMATCH = "tdm"
+	MODE_FIRST_TO_N      = "first-to-n"
+)
+
+// A GameMode decides the rules of a game. It assigns players to teams, decides
+// which players can hurt each other, and decides when a round has been won.
+type GameMode interface {
+	GetType() pb.GameMode
+
+	// GetScoreLimit returns the kills needed to win a round, or 0 if rounds
+	// are not won by kills.
+	GetScoreLimit() uint32
+
+	// AssignTeam returns the team for a new player, given the number of
+	// players already on each team. Modes without teams return NO_TEAM.
+	AssignTeam(sizes map[uint32]int) uint32
+
+	// CanDamage reports whether a player on the attacker's team can damage a
+	// player on the victim's team.
+	CanDamage(attacker uint32, victim uint32) bool
+
+	// GetWinner reports whether r has been won, and if so, returns the winning
+	// player or team. The round timer is handled separately.
+	GetWinner(r *round) (string, uint32, bool)
+
+	// GetLeader returns the player or team which is ahead in r. It decides the
+	// winner when the round timer runs out.
+	GetLeader(r *round) (string, uint32)
+}
+
+// NewGameMode returns the GameMode for mode. Rounds are won with scoreLimit
+// kills, or the mode's default if scoreLimit is 0.
+func NewGameMode(mode pb.GameMode, scoreLimit int) (GameMode, error) {
+	switch mode {
+	case pb.GameMode_GAME_MODE_FREE_FOR_ALL:
+		return &FreeForAllMode{}, nil
+
+	case pb.GameMode_GAME_MODE_TEAM_DEATHMATCH:
+		return &TeamDeathmatchMode{
+			scoreLimit: cmp.Or(uint32(scoreLimit), DEFAULT_TEAM_DEATHMATCH_SCORE_LIMIT),
+		}, nil
+
+	case pb.GameMode_GAME_MODE_FIRST_TO_N:
+		return &FirstToNMode{
+			scoreLimit: cmp.Or(uint32(scoreLimit), DEFAULT_FIRST_TO_N_SCORE_LIMIT),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown game mode %v", mode)
+	}
+}
+
+// ParseGameMode returns the type of the game mode with name.
+func ParseGameMode(name string) (pb.GameMode, error) {
+	switch name {
+	case MODE_FREE_FOR_ALL:
+		return pb.GameMode_GAME_MODE_FREE_FOR_ALL, nil
+	case MODE_TEAM_DEATHMATCH:
+		return pb.GameMode_GAME_MODE_TEAM_DEATHMATCH, nil
+	case MODE_FIRST_TO_N:
+		return pb.GameMode_GAME_MODE_FIRST_TO_N, nil
+	default:
+		return 0, fmt.Errorf("unknown game mode %s", name)
+	}
+}
+
+// FreeForAllMode is an endless game where everyone can hurt everyone. Rounds
+// only end if they are timed.
+type FreeForAllMode struct{}
+
+func (m *FreeForAllMode) GetType() pb.GameMode {
+	return pb.GameMode_GAME_MODE_FREE_FOR_ALL
+}
+
+func (m *FreeForAllMode) GetScoreLimit() uint32 {
+	return 0
+}
+
+func (m *FreeForAllMode) AssignTeam(sizes map[uint32]int) uint32 {
+	return NO_TEAM
+}
+
+func (m *FreeForAllMode) CanDamage(attacker uint32, victim uint32) bool {
+	return true
+}
+
+func (m *FreeForAllMode) GetWinner(r *round) (string, uint32, bool) {
+	return "", NO_TEAM, false
+}
+
+func (m *FreeForAllMode) GetLeader(r *round) (string, uint32) {
+	return r.getTopPlayer(), NO_TEAM
+}
+
+// TeamDeathmatchMode splits players into two teams without friendly fire. The
+// first team to reach the score limit wins the round.
+type TeamDeathmatchMode struct {
+	scoreLimit uint32
+}
+
+func (m *TeamDeathmatchMode) GetType() pb.GameMode {
+	return pb.GameMode_GAME_MODE_TEAM_DEATHMATCH
+}
+
+func (m *TeamDeathmatchMode) GetScoreLimit() uint32 {
+	return m.scoreLimit
+}
+
+func (m *TeamDeathmatchMode) AssignTeam(sizes map[uint32]int) uint32 {
+	if sizes[TEAM_BLUE] < sizes[TEAM_RED] {
+		return TEAM_BLUE
+	}
+	return TEAM_RED
+}
+
+func (m *TeamDeathmatchMode) CanDamage(attacker uint32, victim uint32) bool {
+	return attacker != victim
+}
+
+func (m *TeamDeathmatchMode) GetWinner(r *round) (string, uint32, bool) {
+	kills := r.getTeamKills()
+	for _, team := range []uint32{TEAM_RED, TEAM_BLUE} {
+		if kills[team] >= m.scoreLimit {
+			return "", team, true
+		}
+	}
+	return "", NO_TEAM, false
+}
+
+func (m *TeamDeathmatchMode) GetLeader(r *round) (string, uint32) {
+	kills := r.getTeamKills()
+	switch {
+	case kills[TEAM_RED] > kills[TEAM_BLUE]:
+		return "", TEAM_RED
+	case kills[TEAM_BLUE] > kills[TEAM_RED]:
+		return "", TEAM_BLUE
+	default:
+		return "", NO_TEAM
+	}
+}
+
+// FirstToNMode is a free-for-all where the first player to reach the score
+// limit wins the round.
+type FirstToNMode struct {
+	scoreLimit uint32
+}
+
+func (m *FirstToNMode) GetType() pb.GameMode {
+	return pb.GameMode_GAME_MODE_FIRST_TO_N
+}
+
+func (m *FirstToNMode) GetScoreLimit() uint32 {
+	return m.scoreLimit
+}
+
+func (m *FirstToNMode) AssignTeam(sizes map[uint32]int) uint32 {
+	return NO_TEAM
+}
+
+func (m *FirstToNMode) CanDamage(attacker uint32, victim uint32) bool {
+	return true
+}
+
+func (m *FirstToNMode) GetWinner(r *round) (string, uint32, bool) {
+	id := r.getTopPlayer()
+	if id == "" || r.kills[id] < m.scoreLimit {
+		return "", NO_TEAM, false
+	}
+	return id, NO_TEAM, true
+}
+
+func (m *FirstToNMode) GetLeader(r *round) (string, uint32) {
+	return r.getTopPlayer(), NO_TEAM
+}
@@ -0,0 +1,112 @@
+package game
+
+import (
+	"server/pb"
+	"testing"
+)
+
+var teams = map[string]uint32{"1": TEAM_RED, "2": TEAM_RED, "3": TEAM_BLUE}
+
+func TestGetWinner(t *testing.T) {
+	tests := map[string]struct {
+		mode      pb.GameMode
+		kills     map[string]uint32
+		wantId    string
+		wantTeam  uint32
+		wantIsWon bool
+	}{
+		"free for all is never won": {
+			pb.GameMode_GAME_MODE_FREE_FOR_ALL,
+			map[string]uint32{"1": 100},
+			"",
+			NO_TEAM,
+			false,
+		},
+		"first to n below limit": {
+			pb.GameMode_GAME_MODE_FIRST_TO_N,
+			map[string]uint32{"1": 2, "3": 4},
+			"",
+			NO_TEAM,
+			false,
+		},
+		"first to n at limit": {
+			pb.GameMode_GAME_MODE_FIRST_TO_N,
+			map[string]uint32{"1": 2, "3": 5},
+			"3",
+			NO_TEAM,
+			true,
+		},
+		"team deathmatch sums team kills": {
+			pb.GameMode_GAME_MODE_TEAM_DEATHMATCH,
+			map[string]uint32{"1": 3, "2": 2, "3": 4},
+			"",
+			TEAM_RED,
+			true,
+		},
+		"team deathmatch below limit": {
+			pb.GameMode_GAME_MODE_TEAM_DEATHMATCH,
+			map[string]uint32{"1": 1, "2": 2, "3": 4},
+			"",
+			NO_TEAM,
+			false,
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			mode, err := NewGameMode(test.mode, 5)
+			if err != nil {
+				t.Fatalf("want no error but got %v", err)
+			}
+
+			r := newRound(1, 0, teams)
+			r.kills = test.kills
+			id, team, isWon := mode.GetWinner(r)
+			if id != test.wantId || team != test.wantTeam || isWon != test.wantIsWon {
+				t.Errorf(
+					"want %v, %v, %v but got %v, %v, %v",
+					test.wantId, test.wantTeam, test.wantIsWon,
+					id, team, isWon,
+				)
+			}
+		})
+	}
+}
+
+func TestAssignTeam(t *testing.T) {
+	tests := map[string]struct {
+		mode  pb.GameMode
+		sizes map[uint32]int
+		want  uint32
+	}{
+		"free for all has no teams": {
+			pb.GameMode_GAME_MODE_FREE_FOR_ALL,
+			map[uint32]int{NO_TEAM: 3},
+			NO_TEAM,
+		},
+		"team deathmatch fills red first": {
+			pb.GameMode_GAME_MODE_TEAM_DEATHMATCH,
+			map[uint32]int{},
+			TEAM_RED,
+		},
+		"team deathmatch fills smaller team": {
+			pb.GameMode_GAME_MODE_TEAM_DEATHMATCH,
+			map[uint32]int{TEAM_RED: 2, TEAM_BLUE: 1},
+			TEAM_BLUE,
+		},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			mode, err := NewGameMode(test.mode, 0)
+			if err != nil {
+				t.Fatalf("want no error but got %v", err)
+			}
+
+			got := mode.AssignTeam(test.sizes)
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
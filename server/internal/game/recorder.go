@@ -0,0 +1,132 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"server/pb"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+// A Recorder writes the events of a game to a file, so that the match can be
+// played back later. Each event is written as a length-prefixed Event.
+//
+// A recording starts with a snapshot of the initial game state and the game's
+// seed and config, followed by every join, quit, respawn, resume and applied
+// input, and a delta for every tick. Events caused by bots are marked or left
+// out, since a rebuilt game adds and steers its bots by itself.
+type Recorder struct {
+	file   *os.File
+	writer *bufio.Writer
+	view   *view // sees the entire game
+}
+
+func NewRecorder(path string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		file:   file,
+		writer: bufio.NewWriter(file),
+		view:   newGlobalView(),
+	}, nil
+}
+
+// Record appends event to the recording.
+func (r *Recorder) Record(event *pb.Event) error {
+	_, err := protodelim.MarshalTo(r.writer, event)
+	return err
+}
+
+// Close flushes the recording and closes its file.
+func (r *Recorder) Close() error {
+	err := r.writer.Flush()
+	if err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+// ReadRecording returns the events recorded in the file at path.
+func ReadRecording(path string) ([]*pb.Event, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	events := []*pb.Event{}
+	for {
+		var event pb.Event
+		err := protodelim.UnmarshalFrom(reader, &event)
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+}
+
+// Rebuild replays the commands in a recording into a new game which tells the
+// time with clock. Since games are deterministic, the rebuilt game ends up in
+// the same state as the recorded one. Events which the game produces by itself
+// are skipped.
+func Rebuild(events []*pb.Event, clock Clock) (*Game, error) {
+	if len(events) == 0 || events[0].GetType() != pb.EventType_EVENT_TYPE_SNAPSHOT {
+		return nil, fmt.Errorf("recording has no snapshot")
+	}
+
+	snapshot := events[0].GetSnapshotEventData()
+	config, err := NewConfigFromPb(snapshot.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	g := NewGame(config, snapshot.GetSeed(), clock, nil)
+	for _, event := range events[1:] {
+		switch event.GetType() {
+		case pb.EventType_EVENT_TYPE_JOIN:
+			data := event.GetJoinEventData()
+			if data.GetIsBot() {
+				continue
+			}
+			err := g.AddPlayer(data.GetId(), data.GetUsername())
+			if err != nil {
+				return nil, err
+			}
+
+		case pb.EventType_EVENT_TYPE_QUIT:
+			data := event.GetQuitEventData()
+			if !data.GetIsBot() {
+				g.RemovePlayer(data.GetId())
+			}
+
+		case pb.EventType_EVENT_TYPE_RESPAWN:
+			// Respawns which failed in the recording fail here too
+			data := event.GetRespawnEventData()
+			if !data.GetIsBot() {
+				g.respawnPlayer(data.GetId())
+			}
+
+		case pb.EventType_EVENT_TYPE_RESUME:
+			g.ResumeClient(event.GetResumeEventData().GetId())
+
+		case pb.EventType_EVENT_TYPE_INPUT:
+			data := event.GetInputEventData()
+			g.queueInput(data.GetId(), data)
+
+		case pb.EventType_EVENT_TYPE_DELTA:
+			g.Step()
+		}
+	}
+	return g, nil
+}
@@ -0,0 +1,158 @@
+package game
+
+import (
+	"path/filepath"
+	"server/internal/game/constants"
+	"server/pb"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReadRecording(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "match.rec")
+	recorder, err := NewRecorder(path)
+	if err != nil {
+		t.Fatalf("could not create recorder: %v", err)
+	}
+
+	want := []*pb.Event{
+		{
+			Type: pb.EventType_EVENT_TYPE_SNAPSHOT,
+			Data: &pb.Event_SnapshotEventData_{
+				SnapshotEventData: &pb.Event_SnapshotEventData{Seed: 1},
+			},
+		},
+		{
+			Type: pb.EventType_EVENT_TYPE_JOIN,
+			Data: &pb.Event_JoinEventData_{
+				JoinEventData: &pb.Event_JoinEventData{Id: "1", Username: "user"},
+			},
+		},
+		{
+			Type: pb.EventType_EVENT_TYPE_DELTA,
+			Data: &pb.Event_DeltaEventData_{
+				DeltaEventData: &pb.Event_DeltaEventData{Removed: []string{"2"}, Tick: 1},
+			},
+		},
+	}
+	for _, event := range want {
+		if err := recorder.Record(event); err != nil {
+			t.Fatalf("could not record event: %v", err)
+		}
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("could not close recorder: %v", err)
+	}
+
+	got, err := ReadRecording(path)
+	if err != nil {
+		t.Fatalf("could not read recording: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("want %d events but got %d", len(want), len(got))
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("want %v but got %v", want[i], got[i])
+		}
+	}
+}
+
+// record steps a recorded game with config for ticks. Client "a" joins at the
+// start and fires constantly, while client "b" joins and quits partway
+// through. Client "a" respawns whenever it can, and resumes its session
+// halfway, which restarts its input sequence.
+func record(t *testing.T, path string, config Config, ticks int) *Game {
+	recorder, err := NewRecorder(path)
+	if err != nil {
+		t.Fatalf("could not create recorder: %v", err)
+	}
+
+	clock := NewManualClock(time.UnixMilli(0))
+	g := NewGame(config, 1, clock, recorder)
+	g.AddPlayer("a", "a")
+
+	sequence := uint32(0)
+	for i := range ticks {
+		switch i {
+		case ticks / 4:
+			g.AddPlayer("b", "b")
+		case ticks / 2:
+			g.ResumeClient("a")
+			sequence = 0
+		case 3 * ticks / 4:
+			g.RemovePlayer("b")
+		}
+		if i%constants.FPS == 0 {
+			g.HandleCommand(Command{
+				ClientId: "a",
+				Event:    &pb.Event{Type: pb.EventType_EVENT_TYPE_RESPAWN},
+			})
+		}
+
+		sequence++
+		g.queueInput("a", &pb.Event_InputEventData{
+			Id:           "a",
+			MouseX:       float64(i%90) / 90,
+			MouseY:       0.5,
+			MousePressed: true,
+			Sequence:     sequence,
+			Tick:         uint32(i),
+		})
+		g.Step()
+		clock.Advance(constants.FRAME_DURATION)
+	}
+
+	g.stopRecording()
+	return g
+}
+
+func TestRebuild(t *testing.T) {
+	tests := map[string]struct {
+		mode     pb.GameMode
+		boundary pb.Boundary
+		bots     int
+	}{
+		"free for all":            {pb.GameMode_GAME_MODE_FREE_FOR_ALL, pb.Boundary_BOUNDARY_WRAP, 0},
+		"free for all with bots":  {pb.GameMode_GAME_MODE_FREE_FOR_ALL, pb.Boundary_BOUNDARY_WRAP, 3},
+		"team deathmatch in zone": {pb.GameMode_GAME_MODE_TEAM_DEATHMATCH, pb.Boundary_BOUNDARY_SHRINKING_ZONE, 4},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			mode, _ := NewGameMode(test.mode, 5)
+			boundary, _ := NewBoundary(test.boundary)
+			config := NewConfig(DEFAULT_REWIND_WINDOW, test.bots).WithRules(Rules{
+				Mode:          mode,
+				RoundDuration: 5 * time.Second,
+				WorldSize:     DEFAULT_WORLD_SIZE / 2,
+				Boundary:      boundary,
+			})
+
+			path := filepath.Join(t.TempDir(), "match.rec")
+			original := record(t, path, config, 10*constants.FPS)
+
+			events, err := ReadRecording(path)
+			if err != nil {
+				t.Fatalf("could not read recording: %v", err)
+			}
+			rebuilt, err := Rebuild(events, NewManualClock(time.UnixMilli(0)))
+			if err != nil {
+				t.Fatalf("could not rebuild recording: %v", err)
+			}
+
+			want := original.GetPbEntities()
+			got := rebuilt.GetPbEntities()
+			if len(got) != len(want) {
+				t.Fatalf("want %d entities but got %d", len(want), len(got))
+			}
+			for i := range want {
+				if !proto.Equal(got[i], want[i]) {
+					t.Errorf("want %v but got %v", want[i], got[i])
+				}
+			}
+		})
+	}
+}
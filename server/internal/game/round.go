@@ -0,0 +1,229 @@
+package game
+
+import (
+	"cmp"
+	"log"
+	"server/internal/game/constants"
+	"server/internal/game/entities"
+	"server/pb"
+	"slices"
+)
+
+const (
+	ROUND_INTERMISSION = 10 * constants.FPS // ticks between rounds
+)
+
+// A round tracks the kills in a single round of a game. The game mode decides
+// when a round has been won, after which the next round starts following an
+// intermission.
+type round struct {
+	number    uint32
+	startTick uint32
+	endTick   uint32 // tick when the round ended, if it is over
+	isOver    bool
+	winnerId  string
+	winner    uint32            // winning team
+	kills     map[string]uint32 // kills by each player
+	teams     map[string]uint32 // team of each player, shared with the game
+}
+
+func newRound(number uint32, startTick uint32, teams map[string]uint32) *round {
+	return &round{
+		number:    number,
+		startTick: startTick,
+		endTick:   0,
+		isOver:    false,
+		winnerId:  "",
+		winner:    NO_TEAM,
+		kills:     make(map[string]uint32),
+		teams:     teams,
+	}
+}
+
+// recordKill credits the player with killerId for a kill. Kills after the
+// round is over do not count.
+func (r *round) recordKill(killerId string, victimId string) {
+	if r.isOver || killerId == "" || killerId == victimId {
+		return
+	}
+	r.kills[killerId]++
+}
+
+// getTopPlayer returns the ID of the player with the most kills, or an empty
+// string if nobody has a kill. Ties are broken by ID.
+func (r *round) getTopPlayer() string {
+	topId := ""
+	for _, id := range sortedIds(r.kills) {
+		if r.kills[id] > r.kills[topId] {
+			topId = id
+		}
+	}
+	return topId
+}
+
+// getTeamKills returns the total kills of each team.
+func (r *round) getTeamKills() map[uint32]uint32 {
+	kills := make(map[uint32]uint32)
+	for id, count := range r.kills {
+		kills[r.teams[id]] += count
+	}
+	return kills
+}
+
+// getScores returns the scoreboard for the players in usernames, sorted from
+// most to fewest kills.
+func (r *round) getScores(usernames map[string]string) []*pb.Event_RoundEventData_Score {
+	scores := []*pb.Event_RoundEventData_Score{}
+	for _, id := range sortedIds(usernames) {
+		scores = append(scores, &pb.Event_RoundEventData_Score{
+			Id:       id,
+			Username: usernames[id],
+			Team:     r.teams[id],
+			Kills:    r.kills[id],
+		})
+	}
+	slices.SortStableFunc(scores, func(a, b *pb.Event_RoundEventData_Score) int {
+		return cmp.Compare(b.GetKills(), a.GetKills())
+	})
+	return scores
+}
+
+// updateRound ends the current round once the game mode decides it has been
+// won or its timer runs out, and starts the next round after an intermission.
+func (g *Game) updateRound() {
+	r := g.round
+	if r.isOver {
+		if g.tick-r.endTick >= ROUND_INTERMISSION {
+			g.round = newRound(r.number+1, g.tick, g.teams)
+			g.broadcastRound()
+		}
+		return
+	}
+
+	winnerId, winner, isWon := g.config.Mode.GetWinner(r)
+	isTimeUp := g.config.RoundDuration > 0 &&
+		g.tick-r.startTick >= uint32(g.config.RoundDuration)
+	if !isWon && !isTimeUp {
+		return
+	}
+	if !isWon {
+		winnerId, winner = g.config.Mode.GetLeader(r)
+	}
+
+	r.isOver = true
+	r.endTick = g.tick
+	r.winnerId = winnerId
+	r.winner = winner
+	g.broadcastRound()
+}
+
+// recordDeath credits the player responsible for the death of victim with a
+// kill, and tells every client who died and why.
+func (g *Game) recordDeath(victim entities.Entity) {
+	player, ok := victim.(*entities.Player)
+	if !ok {
+		return
+	}
+
+	killerId := player.GetKillerId()
+	g.round.recordKill(killerId, victim.GetId())
+	if killer, found := g.entities[killerId].(*entities.Player); found && killerId != victim.GetId() {
+		killer.AddScore()
+		g.updated[killerId] = killer
+	}
+
+	event := &pb.Event{
+		Type: pb.EventType_EVENT_TYPE_DEATH,
+		Data: &pb.Event_DeathEventData_{
+			DeathEventData: &pb.Event_DeathEventData{
+				VictimId: victim.GetId(),
+				KillerId: killerId,
+				Cause:    player.GetCause(),
+			},
+		},
+	}
+	g.record(event)
+	err := g.queue("", event)
+	if err != nil {
+		log.Printf("failed to send death: %v", err)
+	}
+}
+
+// assignTeam assigns the player with id to a team chosen by the game mode.
+func (g *Game) assignTeam(id string) uint32 {
+	sizes := make(map[uint32]int)
+	for _, team := range g.teams {
+		sizes[team]++
+	}
+
+	team := g.config.Mode.AssignTeam(sizes)
+	g.teams[id] = team
+	return team
+}
+
+// canDamage reports whether e1 and e2 can damage each other. Players cannot
+// hit themselves with their own projectiles, e.g. when flying into them with a
+// speed boost. Other hits between players, whether direct or through
+// projectiles, are decided by the game mode.
+func (g *Game) canDamage(e1 entities.Entity, e2 entities.Entity) bool {
+	id1, isPlayer1 := getPlayerId(e1)
+	id2, isPlayer2 := getPlayerId(e2)
+	if !isPlayer1 || !isPlayer2 {
+		return true
+	}
+	if id1 == id2 {
+		return false
+	}
+	return g.config.Mode.CanDamage(g.teams[id1], g.teams[id2])
+}
+
+// getPlayerId returns the ID of the player behind entity, which is the owner
+// of a projectile. It reports false if no player is behind entity.
+func getPlayerId(entity entities.Entity) (string, bool) {
+	switch e := entity.(type) {
+	case *entities.Player:
+		return e.GetId(), true
+	case *entities.Projectile:
+		return e.GetOwnerId(), true
+	default:
+		return "", false
+	}
+}
+
+// getRoundEvent serializes the state of the current round.
+func (g *Game) getRoundEvent() *pb.Event {
+	r := g.round
+	data := &pb.Event_RoundEventData{
+		Mode:       g.config.Mode.GetType(),
+		Round:      r.number,
+		IsOver:     r.isOver,
+		Duration:   uint32(g.config.RoundDuration),
+		Scores:     r.getScores(g.usernames),
+		WinnerId:   r.winnerId,
+		WinnerTeam: r.winner,
+		StartTick:  r.startTick,
+	}
+	return &pb.Event{
+		Type: pb.EventType_EVENT_TYPE_ROUND,
+		Data: &pb.Event_RoundEventData_{RoundEventData: data},
+	}
+}
+
+// broadcastRound sends the state of the current round to every client.
+func (g *Game) broadcastRound() {
+	event := g.getRoundEvent()
+	g.record(event)
+
+	err := g.queue("", event)
+	if err != nil {
+		log.Printf("failed to send round: %v", err)
+	}
+}
+
+// sendRound sends the state of the current round to the client with id.
+func (g *Game) sendRound(id string) {
+	err := g.queue(id, g.getRoundEvent())
+	if err != nil {
+		log.Printf("failed to send round to %s: %v", id, err)
+	}
+}
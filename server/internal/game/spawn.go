@@ -0,0 +1,88 @@
+package game
+
+import (
+	"server/internal/game/entities"
+	"server/internal/game/geometry"
+	"server/pb"
+	"slices"
+)
+
+const (
+	SPAWN_ENEMY_DISTANCE   = 1600.0 // min distance from a new player to enemies
+	SPAWN_PLAYER_CLEARANCE = 800.0  // min distance from players to new asteroids and powerups
+)
+
+// isSafeSpawn reports whether entity can be spawned at its current position.
+// Spawns must not overlap other entities. New players must be inside the safe
+// zone and away from enemies, and new asteroids and powerups must be away from
+// every player, so that nothing appears right next to a player. The distance
+// to enemies shrinks with the zone, so that players still fit inside it.
+func (g *Game) isSafeSpawn(entity entities.Entity) bool {
+	enemyDistance := SPAWN_ENEMY_DISTANCE
+	if g.zoneSize > 0 {
+		enemyDistance = min(enemyDistance, g.zoneSize/4)
+	}
+
+	position := entity.GetPosition()
+	isPlayer := entity.GetEntityType() == pb.EntityType_ENTITY_TYPE_PLAYER
+	if entity.GetEntityType() != pb.EntityType_ENTITY_TYPE_ASTEROID &&
+		g.isOutsideZone(position) {
+		return false
+	}
+
+	for _, id := range sortedIds(g.usernames) {
+		player, found := g.entities[id]
+		if !found || id == entity.GetId() {
+			continue
+		}
+
+		distance := g.getDistance(position, player.GetPosition())
+		if isPlayer && distance < enemyDistance &&
+			g.config.Mode.CanDamage(g.teams[entity.GetId()], g.teams[id]) {
+			return false
+		}
+		if !isPlayer && distance < SPAWN_PLAYER_CLEARANCE {
+			return false
+		}
+	}
+
+	return !g.overlaps(entity)
+}
+
+// overlaps reports whether the bounding box of entity overlaps any other
+// entity in the game, including across the edges of a wrapped world.
+func (g *Game) overlaps(entity entities.Entity) bool {
+	boxes := []*geometry.BoundingBox{entity.GetBoundingBox()}
+	if g.isWrapped() {
+		boxes = append(boxes, entity.GetBoundingBox().GetWrappedCopies(g.config.WorldSize)...)
+	}
+
+	for id, other := range g.entities {
+		if id == entity.GetId() || slices.Contains(g.removed, id) {
+			continue
+		}
+		for _, box := range boxes {
+			if overlapsBounds(box, other.GetBoundingBox()) &&
+				box.DidCollide(other.GetBoundingBox()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// overlapsBounds reports whether the axis-aligned bounds of b1 and b2 overlap,
+// which is a cheap check before testing for a collision.
+func overlapsBounds(b1 *geometry.BoundingBox, b2 *geometry.BoundingBox) bool {
+	minX1, maxX1 := b1.HorizontalBounds()
+	minY1, maxY1 := b1.VerticalBounds()
+	minX2, maxX2 := b2.HorizontalBounds()
+	minY2, maxY2 := b2.VerticalBounds()
+	return minX1 <= maxX2 && minX2 <= maxX1 && minY1 <= maxY2 && minY2 <= maxY1
+}
+
+// add puts entity into the game, and marks it to be sent to clients.
+func (g *Game) add(entity entities.Entity) {
+	g.entities[entity.GetId()] = entity
+	g.updated[entity.GetId()] = entity
+}
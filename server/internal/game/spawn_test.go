@@ -0,0 +1,52 @@
+package game
+
+import (
+	"server/internal/game/entities"
+	"server/internal/game/geometry"
+	"testing"
+	"time"
+)
+
+func TestIsSafeSpawn(t *testing.T) {
+	square := geometry.NewRectangleHull(100, 100)
+	tests := map[string]struct {
+		isPlayer bool
+		position geometry.Vector
+		want     bool
+	}{
+		"player far from everything":  {true, geometry.Vector{X: 2000, Y: 2000}, true},
+		"player near an enemy":        {true, geometry.Vector{X: 5000, Y: 4000}, false},
+		"player inside an obstacle":   {true, geometry.Vector{X: 8000, Y: 8000}, false},
+		"pickup far from everything":  {false, geometry.Vector{X: 2000, Y: 2000}, true},
+		"pickup near a player":        {false, geometry.Vector{X: 5000, Y: 5500}, false},
+		"pickup touching an obstacle": {false, geometry.Vector{X: 8090, Y: 8000}, false},
+		"pickup beside an obstacle":   {false, geometry.Vector{X: 8200, Y: 8000}, true},
+		"player near a wrapped enemy": {true, geometry.Vector{X: 9700, Y: 5000}, false},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			clock := NewManualClock(time.UnixMilli(0))
+			g := NewGame(NewConfig(DEFAULT_REWIND_WINDOW, 0), 1, clock, nil)
+			clear(g.entities)
+			g.AddPlayer("enemy", "enemy")
+			g.entities["enemy"].Move(geometry.Vector{X: 5000, Y: 5000}, geometry.Vector{})
+			g.AddPlayer("edge", "edge")
+			g.entities["edge"].Move(geometry.Vector{X: 200, Y: 5000}, geometry.Vector{})
+			g.entities["obstacle"] = entities.NewMockEntity("obstacle", 8000, 8000, 0, square)
+
+			var candidate entities.Entity = entities.NewMockEntity("candidate", 0, 0, 0, square)
+			if test.isPlayer {
+				candidate, _ = g.spawner.SpawnPlayer("candidate", "candidate", func(entities.Entity) bool {
+					return true
+				})
+			}
+			candidate.Move(test.position, geometry.Vector{})
+
+			got := g.isSafeSpawn(candidate)
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package game
+
+import (
+	"server/internal/game/entities"
+	"server/internal/game/geometry"
+	"server/pb"
+)
+
+const (
+	VIEW_HALF_WIDTH  = 2400.0
+	VIEW_HALF_HEIGHT = 1600.0
+)
+
+// A view is the part of the game that a single client can see. Each client
+// only receives deltas for entities within its area of interest (centered on
+// its Player), as well as for entities which are always visible.
+type view struct {
+	id       string // id of the client
+	center   geometry.Vector
+	known    map[string]entityState // last state sent to the client
+	isGlobal bool                   // sees every entity regardless of distance
+}
+
+func newView(id string, center geometry.Vector) *view {
+	return &view{
+		id:     id,
+		center: center,
+		known:  make(map[string]entityState),
+	}
+}
+
+// newGlobalView creates a view which can see the entire game.
+func newGlobalView() *view {
+	return &view{
+		id:       "",
+		known:    make(map[string]entityState),
+		isGlobal: true,
+	}
+}
+
+// isAlwaysVisible reports whether entity should be sent to every client
+// regardless of distance. Players are always sent so that clients can draw
+// the scoreboard and minimap.
+func isAlwaysVisible(entity entities.Entity) bool {
+	return entity.GetEntityType() == pb.EntityType_ENTITY_TYPE_PLAYER
+}
+
+// isWithin reports whether entity's bounding box overlaps with the area of
+// interest of v, moved by offset.
+func (v *view) isWithin(entity entities.Entity, offset geometry.Vector) bool {
+	boundingBox := entity.GetBoundingBox()
+	minX, maxX := boundingBox.HorizontalBounds()
+	minY, maxY := boundingBox.VerticalBounds()
+	center := v.center.Add(&offset)
+	return maxX >= center.X-VIEW_HALF_WIDTH &&
+		minX <= center.X+VIEW_HALF_WIDTH &&
+		maxY >= center.Y-VIEW_HALF_HEIGHT &&
+		minY <= center.Y+VIEW_HALF_HEIGHT
+}
+
+// getVisibleIds returns the IDs of the entities that can be seen from v. The
+// collision index from the current tick is used to narrow down candidates.
+// Entities which were created after the index was built are checked directly.
+// In a wrapped world, entities across the edges of the world are visible too.
+func (g *Game) getVisibleIds(v *view) map[string]bool {
+	visible := make(map[string]bool)
+	if v.isGlobal {
+		for id := range g.entities {
+			visible[id] = true
+		}
+		return visible
+	}
+
+	offsets := g.getWrapOffsets(v.center, VIEW_HALF_WIDTH, VIEW_HALF_HEIGHT)
+	candidates := []string{}
+	if g.index != nil {
+		for _, offset := range offsets {
+			candidates = append(candidates, g.index.QueryHorizontal(
+				v.center.X+offset.X-VIEW_HALF_WIDTH,
+				v.center.X+offset.X+VIEW_HALF_WIDTH,
+			)...)
+		}
+	}
+	for id, entity := range g.entities {
+		if isAlwaysVisible(entity) {
+			visible[id] = true
+		} else if g.index == nil || !g.index.Contains(id) {
+			candidates = append(candidates, id)
+		}
+	}
+
+	for _, id := range candidates {
+		entity, found := g.entities[id]
+		if !found || visible[id] {
+			continue
+		}
+		for _, offset := range offsets {
+			if v.isWithin(entity, offset) {
+				visible[id] = true
+				break
+			}
+		}
+	}
+	return visible
+}
+
+// getViewDelta serializes the changes in the game state as seen from v, in ID
+// order so that recorded deltas are stable. Entities which the client has not
+// seen are sent in full, while entities which the client already knows about
+// only have their changed fields sent.
+// Entities which left the view are reported as hidden, and entities which
+// left the game are reported as removed. If isFull is set, all visible
+// entities are sent in full.
+//
+// The delta also reports the current tick and the last input processed for
+// the client, which the client uses to reconcile its predicted state.
+func (g *Game) getViewDelta(v *view, isFull bool) *pb.Event {
+	updated := []*pb.EntityData{}
+	changed := []*pb.EntityDelta{}
+	removed := []string{}
+	hidden := []string{}
+
+	visible := g.getVisibleIds(v)
+	for _, id := range sortedIds(visible) {
+		data := g.entities[id].GetEntityData()
+		next := newEntityState(data, g.tick)
+
+		previous, found := v.known[id]
+		if isFull || !found {
+			updated = append(updated, data)
+		} else if _, isUpdated := g.updated[id]; isUpdated {
+			if delta := previous.diff(id, next); delta != nil {
+				changed = append(changed, delta)
+			}
+		}
+		v.known[id] = next
+	}
+
+	for _, id := range sortedIds(v.known) {
+		if visible[id] {
+			continue
+		}
+		if _, found := g.entities[id]; found {
+			hidden = append(hidden, id)
+		} else {
+			removed = append(removed, id)
+		}
+		delete(v.known, id)
+	}
+
+	return &pb.Event{
+		Type: pb.EventType_EVENT_TYPE_DELTA,
+		Data: &pb.Event_DeltaEventData_{
+			DeltaEventData: &pb.Event_DeltaEventData{
+				Timestamp:     g.GetTimestamp(),
+				Updated:       updated,
+				Removed:       removed,
+				Changed:       changed,
+				Hidden:        hidden,
+				Tick:          g.tick,
+				InputSequence: g.sequences[v.id],
+				ZoneSize:      g.zoneSize,
+			},
+		},
+	}
+}
+
+// getViewSnapshot serializes the game state as seen from v.
+func (g *Game) getViewSnapshot(v *view) *pb.Event {
+	visible := g.getVisibleIds(v)
+	entities := make([]*pb.EntityData, 0, len(visible))
+	for _, id := range sortedIds(visible) {
+		entities = append(entities, g.entities[id].GetEntityData())
+	}
+
+	return &pb.Event{
+		Type: pb.EventType_EVENT_TYPE_SNAPSHOT,
+		Data: &pb.Event_SnapshotEventData_{
+			SnapshotEventData: &pb.Event_SnapshotEventData{
+				Timestamp: g.GetTimestamp(),
+				Entities:  entities,
+				WorldSize: g.config.WorldSize,
+				Boundary:  g.config.Boundary.GetType(),
+				ZoneSize:  g.zoneSize,
+			},
+		},
+	}
+}
@@ -6,21 +6,52 @@ import (
 	"github.com/sqids/sqids-go"
 )
 
+const (
+	NAMESPACE_GLOBAL = 0 // IDs for clients and rooms
+	NAMESPACE_GAME   = 1 // IDs for entities within a single game
+)
+
 var (
-	counter    uint64 = 0
-	encoder, _        = sqids.New(sqids.Options{MinLength: 6})
-	mu         sync.Mutex
+	encoder, _ = sqids.New(sqids.Options{MinLength: 6})
+	global     = NewGenerator(NAMESPACE_GLOBAL)
 )
 
-func NewShortId() (string, error) {
-	mu.Lock()
-	defer mu.Unlock()
+// A Generator generates a deterministic sequence of short IDs. IDs from
+// generators with different namespaces never collide.
+type Generator struct {
+	namespace uint64
+	counter   uint64
+	mu        sync.Mutex
+}
+
+func NewGenerator(namespace uint64) *Generator {
+	return &Generator{
+		namespace: namespace,
+		counter:   0,
+		mu:        sync.Mutex{},
+	}
+}
 
-	encoded, err := encoder.Encode([]uint64{counter})
+// NewShortId returns the next ID in the generator's sequence.
+func (g *Generator) NewShortId() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	numbers := []uint64{g.counter}
+	if g.namespace != NAMESPACE_GLOBAL {
+		numbers = append(numbers, g.namespace)
+	}
+
+	encoded, err := encoder.Encode(numbers)
 	if err != nil {
 		return "", err
 	}
 
-	counter++
+	g.counter++
 	return encoded, nil
 }
+
+// NewShortId returns the next ID from the process-wide generator.
+func NewShortId() (string, error) {
+	return global.NewShortId()
+}
@@ -0,0 +1,170 @@
+package replay
+
+import (
+	"log"
+	"net/http"
+	"server/internal/game/constants"
+	"server/pb"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	REPLAY_CLIENT_ID = "replay"
+	REPLAY_TOKEN     = "replay"
+)
+
+// A Server plays back a recorded match over the same API as the master and
+// workers, so that the client can watch it as a spectator.
+type Server struct {
+	host   string
+	port   string
+	events []*pb.Event
+}
+
+func NewServer(host string, port string, events []*pb.Event) *Server {
+	return &Server{
+		host:   host,
+		port:   port,
+		events: events,
+	}
+}
+
+func (s *Server) Serve() {
+	corsHandler := cors.Handler(cors.Options{
+		AllowedOrigins:   []string{"http://localhost:5173"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowCredentials: true,
+	})
+
+	r := chi.NewRouter()
+	r.Use(corsHandler)
+	r.Use(middleware.Logger)
+
+	buildDir := http.Dir("../client/dist")
+	fs := http.FileServer(buildDir)
+	r.Handle("/*", fs)
+
+	r.Post("/api/join", s.HandleJoin)
+	r.Get("/api/room/snapshot", s.HandleSnapshot)
+	r.Get("/api/room/ws", s.HandleWS)
+
+	log.Printf("replay server is running on http://%s%s", s.host, s.port)
+	if err := http.ListenAndServe(s.port, r); err != nil {
+		log.Fatalf("failed to start server: %v", err)
+	}
+}
+
+// HandleJoin lets any client join the replay as a spectator.
+func (s *Server) HandleJoin(w http.ResponseWriter, r *http.Request) {
+	body, err := proto.Marshal(&pb.JoinResponse{
+		ClientId:  REPLAY_CLIENT_ID,
+		Host:      s.host + s.port,
+		Token:     REPLAY_TOKEN,
+		Spectator: true,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if _, err = w.Write(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleSnapshot returns the initial state of the recorded match.
+func (s *Server) HandleSnapshot(w http.ResponseWriter, r *http.Request) {
+	if len(s.events) == 0 || s.events[0].GetType() != pb.EventType_EVENT_TYPE_SNAPSHOT {
+		http.Error(w, "recording has no snapshot", http.StatusNotFound)
+		return
+	}
+
+	body, err := proto.Marshal(s.events[0])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if _, err = w.Write(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// HandleWS streams the recorded match to the client, one delta per frame.
+func (s *Server) HandleWS(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{
+		CheckOrigin: func(r *http.Request) bool {
+			return true
+		},
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	go s.play(conn)
+}
+
+// play sends the recorded events which the client can handle. Inputs,
+// respawns and resumes are only needed to rebuild the match, so they are
+// skipped.
+func (s *Server) play(conn *websocket.Conn) {
+	defer conn.Close()
+
+	// Discard messages from the client, and stop once it disconnects
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(constants.FRAME_DURATION)
+	defer ticker.Stop()
+
+	for _, event := range s.events {
+		switch event.GetType() {
+		case pb.EventType_EVENT_TYPE_DELTA:
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+		case pb.EventType_EVENT_TYPE_JOIN,
+			pb.EventType_EVENT_TYPE_QUIT,
+			pb.EventType_EVENT_TYPE_ROUND,
+			pb.EventType_EVENT_TYPE_DEATH:
+			// Sent as soon as they are reached
+
+		default:
+			continue
+		}
+
+		data, err := proto.Marshal(event)
+		if err != nil {
+			log.Printf("failed to encode %v: %v", event.GetType(), err)
+			continue
+		}
+		if err := conn.WriteMessage(websocket.BinaryMessage, data); err != nil {
+			return
+		}
+	}
+	log.Printf("finished replay")
+}
@@ -1,51 +1,131 @@
 package room
 
 import (
+	"context"
+	"fmt"
+	"log"
+	"server/internal/game"
+	"server/pb"
 	"sync"
 
 	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
 )
 
+// MAX_PROTOCOL_VIOLATIONS is the number of invalid messages a client can send
+// before it is disconnected.
+const MAX_PROTOCOL_VIOLATIONS = 16
+
 // A Client manages the interaction between the user and the server.
 type Client struct {
-	id       string
-	username string
-	conn     *websocket.Conn
-	send     chan []byte
-	mu       sync.Mutex
+	id          string
+	username    string
+	isSpectator bool
+	conn        *websocket.Conn
+	send        chan []byte
+	done        chan struct{} // closed once the connection is closed
+	mu          sync.Mutex
+	once        sync.Once
+
+	violations int // number of invalid messages received
 }
 
-func newClient(id string, username string, conn *websocket.Conn) *Client {
+func newClient(
+	id string,
+	username string,
+	isSpectator bool,
+	conn *websocket.Conn,
+) *Client {
 	return &Client{
-		id:       id,
-		username: username,
-		send:     make(chan []byte),
-		mu:       sync.Mutex{},
-		conn:     conn,
+		id:          id,
+		username:    username,
+		isSpectator: isSpectator,
+		send:        make(chan []byte),
+		done:        make(chan struct{}),
+		mu:          sync.Mutex{},
+		once:        sync.Once{},
+		conn:        conn,
 	}
 }
 
-// readPump relays messages from the client to the incoming channel.
-func (c *Client) readPump(incoming chan<- []byte) {
-	defer c.conn.Close()
+// close closes the client's conn and stops its write pump.
+func (c *Client) close() {
+	c.once.Do(func() {
+		close(c.done)
+		c.conn.Close()
+	})
+}
 
-	for {
+// deliver queues data to be written to the client. The data is dropped if the
+// client's connection has been closed.
+func (c *Client) deliver(data []byte) {
+	select {
+	case <-c.done:
+	case c.send <- data:
+	}
+}
+
+// readPump decodes messages from the client and relays them to the incoming
+// channel as commands, tagged with the client's authenticated ID. Messages
+// which cannot be decoded, contain events which clients are not allowed to
+// send, or claim to be sent by another client are dropped and counted as
+// protocol violations. readPump returns once the connection is closed, the
+// client has sent too many invalid messages, or ctx is done.
+func (c *Client) readPump(ctx context.Context, incoming chan<- game.Command) {
+	defer c.close()
+
+	for c.violations < MAX_PROTOCOL_VIOLATIONS {
 		_, message, err := c.conn.ReadMessage()
 		if err != nil {
-			break
+			return
+		}
+
+		var event pb.Event
+		err = proto.Unmarshal(message, &event)
+		if err != nil {
+			c.reportViolation("could not decode message: %v", err)
+			continue
+		}
+		if !game.IsClientEventType(event.GetType()) {
+			c.reportViolation("sent %v", event.GetType())
+			continue
+		}
+		if id := game.GetSenderId(&event); id != "" && id != c.id {
+			c.reportViolation("sent %v as %s", event.GetType(), id)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case incoming <- game.Command{ClientId: c.id, Event: &event}:
 		}
-		incoming <- message
 	}
+	log.Printf("disconnected %s after %d protocol violations", c.id, c.violations)
 }
 
-// writePump relays messages from the room to the client.
+// reportViolation logs an invalid message from the client and counts it
+// towards MAX_PROTOCOL_VIOLATIONS.
+func (c *Client) reportViolation(format string, v ...any) {
+	c.violations++
+	log.Printf("protocol violation from %s: %s", c.id, fmt.Sprintf(format, v...))
+}
+
+// writePump relays messages from the room to the client until the connection
+// is closed.
 func (c *Client) writePump() {
-	defer c.conn.Close()
+	defer c.close()
 
-	for data := range c.send {
-		err := c.writeMessage(data)
-		if err != nil {
-			break
+	for {
+		select {
+		case <-c.done:
+			return
+
+		case data := <-c.send:
+			err := c.writeMessage(data)
+			if err != nil {
+				return
+			}
 		}
 	}
 }
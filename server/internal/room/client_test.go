@@ -0,0 +1,132 @@
+package room
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"server/pb"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+const TEST_TIMEOUT = time.Second
+
+// newInputMessage returns an encoded input event which claims to be sent by
+// the client with id.
+func newInputMessage(t *testing.T, id string) []byte {
+	message, err := proto.Marshal(&pb.Event{
+		Type: pb.EventType_EVENT_TYPE_INPUT,
+		Data: &pb.Event_InputEventData_{
+			InputEventData: &pb.Event_InputEventData{Id: id},
+		},
+	})
+	if err != nil {
+		t.Fatalf("could not encode input: %v", err)
+	}
+	return message
+}
+
+// connectTestClient connects a client with clientId to r over a websocket,
+// and returns the client's end of the connection. The error from InitClient
+// is sent on the returned channel, and the connection is closed on errors.
+func connectTestClient(
+	t *testing.T,
+	r *Room,
+	clientId string,
+	isSpectator bool,
+) (*websocket.Conn, <-chan error) {
+	upgrader := websocket.Upgrader{}
+	errs := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			t.Errorf("could not upgrade: %v", err)
+			return
+		}
+		err = r.InitClient(clientId, clientId, isSpectator, conn)
+		if err != nil {
+			conn.Close()
+		}
+		errs <- err
+	}))
+	t.Cleanup(server.Close)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("could not dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, errs
+}
+
+func TestReadPumpViolations(t *testing.T) {
+	snapshot, _ := proto.Marshal(&pb.Event{Type: pb.EventType_EVENT_TYPE_SNAPSHOT})
+	tests := map[string]struct {
+		message func(t *testing.T) []byte
+	}{
+		"Undecodable message": {func(t *testing.T) []byte { return []byte{0xff, 0xff} }},
+		"Spoofed sender":      {func(t *testing.T) []byte { return newInputMessage(t, "b") }},
+		"Server event":        {func(t *testing.T) []byte { return snapshot }},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			r := newTestRoom()
+			t.Cleanup(r.stop)
+			conn, errs := connectTestClient(t, r, "a", true)
+			if err := <-errs; err != nil {
+				t.Fatalf("could not init client: %v", err)
+			}
+
+			// The invalid message is dropped, so the valid one arrives first
+			write(t, conn, test.message(t))
+			write(t, conn, newInputMessage(t, "a"))
+			select {
+			case command := <-r.game.Incoming:
+				got := command.Event.GetInputEventData().GetId()
+				if command.ClientId != "a" || got != "a" {
+					t.Errorf("want input from a but got %s as %s", command.ClientId, got)
+				}
+			case <-time.After(TEST_TIMEOUT):
+				t.Fatalf("want valid input to be relayed")
+			}
+
+			// The client is disconnected once it reaches the limit
+			for range MAX_PROTOCOL_VIOLATIONS - 1 {
+				write(t, conn, test.message(t))
+			}
+			conn.SetReadDeadline(time.Now().Add(TEST_TIMEOUT))
+			_, _, err := conn.ReadMessage()
+			if netErr, ok := err.(net.Error); err == nil || ok && netErr.Timeout() {
+				t.Fatalf("want connection to be closed but got %v", err)
+			}
+
+			// The room removes the client after its read pump returns
+			deadline := time.Now().Add(TEST_TIMEOUT)
+			for !r.IsDisconnected("a") && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			if !r.IsDisconnected("a") {
+				t.Errorf("want client to be disconnected")
+			}
+
+			select {
+			case command := <-r.game.Incoming:
+				t.Errorf("want no more commands but got %v", command.Event)
+			default:
+			}
+		})
+	}
+}
+
+func write(t *testing.T, conn *websocket.Conn, message []byte) {
+	err := conn.WriteMessage(websocket.BinaryMessage, message)
+	if err != nil {
+		t.Fatalf("could not write: %v", err)
+	}
+}
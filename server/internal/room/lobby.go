@@ -1,22 +1,53 @@
 package room
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"path/filepath"
+	"server/internal/game"
 	"server/pb"
+	"slices"
 	"sync"
+	"time"
 )
 
+const (
+	IDLE_CHECK_INTERVAL = 10 * time.Second
+	CLOSED_ROOM_TTL     = 5 * time.Minute // how long closed rooms are reported
+)
+
+// ErrRoomExists is returned when creating a room with an ID which is in use.
+var ErrRoomExists = errors.New("room already exists")
+
 // A Lobby manages rooms.
 type Lobby struct {
-	rooms   map[string]*Room
-	roomIds []string
-	mu      sync.Mutex
+	rooms          map[string]*Room
+	roomIds        []string
+	closedRooms    map[string]time.Time // when each recently closed room closed
+	config         game.Config          // settings for new rooms
+	idleTimeout    time.Duration        // how long a room can be empty before closing
+	reconnectGrace time.Duration        // how long clients have to reconnect
+	recordDir      string               // where matches are recorded, or empty
+	mu             sync.Mutex
 }
 
-func NewLobby() *Lobby {
+func NewLobby(
+	config game.Config,
+	idleTimeout time.Duration,
+	reconnectGrace time.Duration,
+	recordDir string,
+) *Lobby {
 	return &Lobby{
-		rooms:   map[string]*Room{},
-		roomIds: []string{},
-		mu:      sync.Mutex{},
+		rooms:          map[string]*Room{},
+		roomIds:        []string{},
+		closedRooms:    map[string]time.Time{},
+		config:         config,
+		idleTimeout:    idleTimeout,
+		reconnectGrace: reconnectGrace,
+		recordDir:      recordDir,
+		mu:             sync.Mutex{},
 	}
 }
 
@@ -28,20 +59,77 @@ func (l *Lobby) GetRoom(roomId string) *Room {
 	return l.rooms[roomId]
 }
 
-// CreateRoom creates a new room with roomId.
-func (l *Lobby) CreateRoom(roomId string) {
+// CreateRoom creates a new room with roomId, whose game follows rules. The
+// room accepts at most maxPlayers players, or any number if maxPlayers is 0.
+// If the lobby has a record directory, the room's match is recorded there. It
+// returns ErrRoomExists if a room with roomId is already running.
+func (l *Lobby) CreateRoom(roomId string, rules game.Rules, maxPlayers int) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
-	room := newRoom(roomId)
+	if _, found := l.rooms[roomId]; found {
+		return fmt.Errorf("%w: %s", ErrRoomExists, roomId)
+	}
+
+	var recorder *game.Recorder = nil
+	if l.recordDir != "" {
+		name := fmt.Sprintf("%s-%d.rec", roomId, time.Now().Unix())
+		r, err := game.NewRecorder(filepath.Join(l.recordDir, name))
+		if err != nil {
+			return err
+		}
+		recorder = r
+	}
+
+	config := l.config.WithRules(rules)
+	game := game.NewGame(config, rand.Uint32(), game.SystemClock, recorder)
+	room := newRoom(roomId, game, maxPlayers, l.reconnectGrace)
 	room.init()
 
 	l.rooms[roomId] = room
 	l.roomIds = append(l.roomIds, roomId)
+	delete(l.closedRooms, roomId)
+	return nil
+}
+
+// CloseIdleRooms periodically stops and removes rooms which have been empty
+// for longer than the idle timeout.
+func (l *Lobby) CloseIdleRooms() {
+	ticker := time.NewTicker(IDLE_CHECK_INTERVAL)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		for _, room := range l.removeIdleRooms() {
+			room.stop()
+			log.Printf("closed idle room %s", room.id)
+		}
+	}
+}
+
+// removeIdleRooms removes idle rooms from the lobby, so that no new clients
+// can join them, and returns them.
+func (l *Lobby) removeIdleRooms() []*Room {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	idle := []*Room{}
+	for roomId, room := range l.rooms {
+		if !room.isIdle(l.idleTimeout) {
+			continue
+		}
+		idle = append(idle, room)
+		delete(l.rooms, roomId)
+		l.roomIds = slices.DeleteFunc(l.roomIds, func(id string) bool {
+			return id == roomId
+		})
+		l.closedRooms[roomId] = time.Now()
+	}
+	return idle
 }
 
-// GetSnapshot gets the game state for the requested room.
-func (l *Lobby) GetSnapshot(roomId string) *pb.Event {
+// GetSnapshot gets the game state for the requested room, as seen by the
+// client with clientId.
+func (l *Lobby) GetSnapshot(roomId string, clientId string) *pb.Event {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
@@ -49,9 +137,12 @@ func (l *Lobby) GetSnapshot(roomId string) *pb.Event {
 	if !found {
 		return nil
 	}
-	return room.game.GetSnapshot()
+	return room.game.GetSnapshot(clientId)
 }
 
+// GetStatus reports the occupancy of each room, as well as the rooms which
+// have been closed. Closed rooms are reported until CLOSED_ROOM_TTL has passed,
+// so that they are not missed if a report does not reach the master.
 func (l *Lobby) GetStatus() *pb.StatusResponse {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -66,7 +157,18 @@ func (l *Lobby) GetStatus() *pb.StatusResponse {
 		i++
 	}
 
+	closedRoomIds := []string{}
+	for roomId, closedAt := range l.closedRooms {
+		if time.Since(closedAt) >= CLOSED_ROOM_TTL {
+			delete(l.closedRooms, roomId)
+			continue
+		}
+		closedRoomIds = append(closedRoomIds, roomId)
+	}
+	slices.Sort(closedRoomIds)
+
 	return &pb.StatusResponse{
-		RoomStatuses: roomStatuses,
+		RoomStatuses:  roomStatuses,
+		ClosedRoomIds: closedRoomIds,
 	}
 }
@@ -0,0 +1,93 @@
+package room
+
+import (
+	"errors"
+	"server/internal/game"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newTestLobby returns a lobby whose rooms are not running, with a room for
+// each ID in roomIds.
+func newTestLobby(roomIds ...string) *Lobby {
+	lobby := NewLobby(game.NewConfig(game.DEFAULT_REWIND_WINDOW, 0), time.Minute, time.Hour, "")
+	for _, roomId := range roomIds {
+		lobby.rooms[roomId] = newTestRoom()
+		lobby.roomIds = append(lobby.roomIds, roomId)
+	}
+	return lobby
+}
+
+func TestCreateRoom(t *testing.T) {
+	lobby := newTestLobby("taken")
+
+	err := lobby.CreateRoom("taken", game.Rules{}, 0)
+	if !errors.Is(err, ErrRoomExists) {
+		t.Errorf("want %v but got %v", ErrRoomExists, err)
+	}
+	if len(lobby.rooms) != 1 {
+		t.Errorf("want 1 room but got %d", len(lobby.rooms))
+	}
+}
+
+func TestRemoveIdleRooms(t *testing.T) {
+	tests := map[string]struct {
+		emptyFor    time.Duration
+		hasClient   bool
+		wantRemoved bool
+	}{
+		"Recently emptied":   {time.Second, false, false},
+		"Empty for too long": {time.Hour, false, true},
+		"Has a client":       {time.Hour, true, false},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			lobby := newTestLobby("room")
+			room := lobby.rooms["room"]
+			room.emptySince = time.Now().Add(-test.emptyFor)
+			if test.hasClient {
+				room.add(newClient("a", "a", false, nil))
+			}
+
+			removed := lobby.removeIdleRooms()
+			if (len(removed) == 1) != test.wantRemoved {
+				t.Errorf("want removed %v but got %v", test.wantRemoved, removed)
+			}
+			if (lobby.GetRoom("room") == nil) != test.wantRemoved {
+				t.Errorf("want room to be removed from the lobby %v", test.wantRemoved)
+			}
+
+			closed := lobby.GetStatus().ClosedRoomIds
+			if slices.Contains(closed, "room") != test.wantRemoved {
+				t.Errorf("want closed %v but got %v", test.wantRemoved, closed)
+			}
+		})
+	}
+}
+
+func TestGetStatusClosedRooms(t *testing.T) {
+	tests := map[string]struct {
+		closedFor time.Duration
+		want      []string
+	}{
+		"Recently closed": {time.Second, []string{"room"}},
+		"Closed too long": {CLOSED_ROOM_TTL, []string{}},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			lobby := newTestLobby()
+			lobby.closedRooms["room"] = time.Now().Add(-test.closedFor)
+
+			// Closed rooms are reported more than once, until they expire
+			for range 2 {
+				got := lobby.GetStatus().ClosedRoomIds
+				if !slices.Equal(got, test.want) {
+					t.Errorf("want %v but got %v", test.want, got)
+				}
+			}
+		})
+	}
+}
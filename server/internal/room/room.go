@@ -2,49 +2,115 @@ package room
 
 import (
 	"context"
+	"fmt"
 	"server/internal/game"
 	"server/pb"
 
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"google.golang.org/protobuf/proto"
 )
 
+const (
+	DEFAULT_IDLE_TIMEOUT    = 5 * time.Minute
+	DEFAULT_RECONNECT_GRACE = 30 * time.Second
+)
+
 // A Room allows multiple clients to connect, and runs a single game instance.
 type Room struct {
-	id      string
-	game    *game.Game
-	clients map[string]*Client
+	id         string
+	game       *game.Game
+	clients    map[string]*Client
+	emptySince time.Time // when the last client left
+	maxPlayers int       // or 0 if unlimited
+
+	// Clients which lost their connection, but can still reconnect.
+	disconnected   map[string]*disconnection
+	reconnectGrace time.Duration
 
-	mu     sync.Mutex
-	ctx    context.Context
-	cancel context.CancelFunc
+	isStopped bool // set once the room stops, so clients are not kept
+	mu        sync.Mutex
+	ctx       context.Context
+	cancel    context.CancelFunc
 }
 
-func newRoom(id string) *Room {
+// A disconnection is a client which lost its connection. Its Player is
+// removed once the timer fires, unless the client reconnects first.
+type disconnection struct {
+	client *Client
+	timer  *time.Timer
+}
+
+func newRoom(
+	id string,
+	game *game.Game,
+	maxPlayers int,
+	reconnectGrace time.Duration,
+) *Room {
 	ctx, cancel := context.WithCancel(context.Background())
-	game := game.NewGame()
 
 	return &Room{
-		id:      id,
-		game:    game,
-		clients: map[string]*Client{},
-		mu:      sync.Mutex{},
-		ctx:     ctx,
-		cancel:  cancel,
+		id:         id,
+		game:       game,
+		clients:    map[string]*Client{},
+		emptySince: time.Now(),
+		maxPlayers: maxPlayers,
+		mu:         sync.Mutex{},
+		ctx:        ctx,
+		cancel:     cancel,
+
+		disconnected:   map[string]*disconnection{},
+		reconnectGrace: reconnectGrace,
 	}
 }
 
-func (r *Room) InitClient(clientId string, username string, conn *websocket.Conn) {
+// InitClient connects a client to the room. Spectators can see the game, but
+// do not get a Player. It returns an error if the client is already connected,
+// so that a live session cannot be taken over by another connection, or if
+// the client's Player could not be spawned.
+func (r *Room) InitClient(
+	clientId string,
+	username string,
+	isSpectator bool,
+	conn *websocket.Conn,
+) error {
+	client := newClient(clientId, username, isSpectator, conn)
+	isResuming, err := r.add(client)
+	if err != nil {
+		return err
+	}
+
 	conn.SetCloseHandler(func(code int, text string) error {
-		r.remove(clientId)
+		r.remove(client)
 		return nil
 	})
+	err = r.connect(client, isResuming)
+	if err != nil {
+		r.discard(client)
+		return err
+	}
+	return nil
+}
 
-	client := newClient(clientId, username, conn)
-	r.add(client)
-	r.connect(client)
+// HasSpace reports whether the client with clientId can connect to the room.
+// Spectators and clients which are resuming their session always have space.
+func (r *Room) HasSpace(clientId string, isSpectator bool) bool {
+	if r.maxPlayers == 0 || isSpectator || r.IsDisconnected(clientId) {
+		return true
+	}
+	return int(r.getOccupancy()) < r.maxPlayers
+}
+
+// IsDisconnected reports whether the client with clientId lost its connection
+// and can still resume its session.
+func (r *Room) IsDisconnected(clientId string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, isDisconnected := r.disconnected[clientId]
+	return isDisconnected
 }
 
 func (r *Room) init() {
@@ -52,31 +118,141 @@ func (r *Room) init() {
 	go r.broadcast()
 }
 
-func (r *Room) add(client *Client) {
+// add adds a client to the room. It reports whether the client is resuming an
+// existing session by reconnecting within the grace period. Clients whose
+// previous connection has not been closed yet cannot be added.
+func (r *Room) add(client *Client) (bool, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if _, isConnected := r.clients[client.id]; isConnected {
+		return false, fmt.Errorf("client %s is already connected", client.id)
+	}
+
+	disconnected, isDisconnected := r.disconnected[client.id]
+	if isDisconnected {
+		disconnected.timer.Stop()
+		delete(r.disconnected, client.id)
+	}
+
 	r.clients[client.id] = client
+	return isDisconnected, nil
+}
+
+// remove removes a client from the room once its connection is closed. Its
+// Player is kept until the reconnect grace period has passed, unless the room
+// has stopped.
+func (r *Room) remove(client *Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The client may already have been removed by its close handler
+	if r.clients[client.id] != client {
+		return
+	}
+
+	delete(r.clients, client.id)
+	if len(r.clients) == 0 {
+		r.emptySince = time.Now()
+	}
+	if r.isStopped {
+		return
+	}
+	r.disconnected[client.id] = &disconnection{
+		client: client,
+		timer: time.AfterFunc(r.reconnectGrace, func() {
+			r.expire(client)
+		}),
+	}
 }
 
-func (r *Room) remove(clientId string) {
+// discard removes a client which could not join the game, without keeping a
+// session for it to resume.
+func (r *Room) discard(client *Client) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.game.RemovePlayer(clientId)
-	delete(r.clients, clientId)
+	if r.clients[client.id] == client {
+		delete(r.clients, client.id)
+		if len(r.clients) == 0 {
+			r.emptySince = time.Now()
+		}
+	}
+
+	// The connection may have closed before the Player failed to spawn
+	if disconnected, found := r.disconnected[client.id]; found && disconnected.client == client {
+		disconnected.timer.Stop()
+		delete(r.disconnected, client.id)
+	}
 }
 
+// expire removes the Player of a client which did not reconnect in time, and
+// sends a quit event message to other clients.
+func (r *Room) expire(client *Client) error {
+	r.mu.Lock()
+	_, found := r.disconnected[client.id]
+	delete(r.disconnected, client.id)
+	r.mu.Unlock()
+
+	if !found {
+		return nil
+	}
+
+	if client.isSpectator {
+		r.game.RemoveSpectator(client.id)
+		return nil
+	}
+	r.game.RemovePlayer(client.id)
+	return r.sendQuitEvent(client.id)
+}
+
+// isIdle reports whether the room has had no clients for at least timeout.
+func (r *Room) isIdle(timeout time.Duration) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.clients) == 0 && time.Since(r.emptySince) >= timeout
+}
+
+// stop stops the game loop and broadcaster, and disconnects any remaining
+// clients.
 func (r *Room) stop() {
 	r.cancel()
+
+	r.mu.Lock()
+	r.isStopped = true
+	for _, disconnected := range r.disconnected {
+		disconnected.timer.Stop()
+	}
+	clear(r.disconnected)
+	r.mu.Unlock()
+
+	for _, client := range r.getClients() {
+		client.close()
+	}
 }
 
 // connect allows clients to connect to the room, and sends a join event
-// message to other clients.
-func (r *Room) connect(client *Client) error {
-	go client.readPump(r.game.Incoming)
+// message to other clients. Resuming clients get their existing Player back
+// instead, and spectators are added silently. The client is removed once it
+// stops reading.
+func (r *Room) connect(client *Client, isResuming bool) error {
+	go func() {
+		client.readPump(r.ctx, r.game.Incoming)
+		r.remove(client)
+	}()
 	go client.writePump()
 
+	if isResuming {
+		r.game.ResumeClient(client.id)
+		return nil
+	}
+
+	if client.isSpectator {
+		r.game.AddSpectator(client.id)
+		return nil
+	}
+
 	err := r.game.AddPlayer(client.id, client.username)
 	if err != nil {
 		return err
@@ -84,7 +260,7 @@ func (r *Room) connect(client *Client) error {
 	return r.sendJoinEvent(client.id, client.username)
 }
 
-// broadcast relays messages from the game to all clients.
+// broadcast relays messages from the game to their recipients.
 func (r *Room) broadcast() {
 	for {
 		select {
@@ -92,24 +268,20 @@ func (r *Room) broadcast() {
 			return
 
 		case message := <-r.game.Outgoing:
-			for _, client := range r.clients {
-				client.send <- message
+			if message.ClientId != "" {
+				r.sendTo(message.ClientId, message.Data)
+				continue
 			}
 
-			var event pb.Event
-			proto.Unmarshal(message, &event)
-
-			switch event.Type {
-			case pb.EventType_EVENT_TYPE_QUIT:
-				data := event.GetQuitEventData()
-				r.remove(data.GetId())
+			for _, client := range r.getClients() {
+				client.deliver(message.Data)
 			}
 		}
 	}
 }
 
 func (r *Room) sendJoinEvent(id string, username string) error {
-	data := &pb.Event{
+	return r.sendEvent(&pb.Event{
 		Type: pb.EventType_EVENT_TYPE_JOIN,
 		Data: &pb.Event_JoinEventData_{
 			JoinEventData: &pb.Event_JoinEventData{
@@ -117,20 +289,72 @@ func (r *Room) sendJoinEvent(id string, username string) error {
 				Username: username,
 			},
 		},
-	}
+	})
+}
+
+func (r *Room) sendQuitEvent(id string) error {
+	return r.sendEvent(&pb.Event{
+		Type: pb.EventType_EVENT_TYPE_QUIT,
+		Data: &pb.Event_QuitEventData_{
+			QuitEventData: &pb.Event_QuitEventData{
+				Id: id,
+			},
+		},
+	})
+}
 
+// sendEvent sends an event generated by the room to all clients.
+func (r *Room) sendEvent(data *pb.Event) error {
 	message, err := proto.Marshal(data)
 	if err != nil {
 		return err
 	}
-	r.game.Outgoing <- message
+	select {
+	case <-r.ctx.Done():
+	case r.game.Outgoing <- game.Message{Data: message}:
+	}
 	return nil
 }
 
-// getOccupancy returns the current number of connected players
+// sendTo sends a message to the client with clientId, if it is connected.
+func (r *Room) sendTo(clientId string, message []byte) {
+	r.mu.Lock()
+	client, found := r.clients[clientId]
+	r.mu.Unlock()
+
+	if found {
+		client.deliver(message)
+	}
+}
+
+// getClients returns the currently connected clients.
+func (r *Room) getClients() []*Client {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clients := make([]*Client, 0, len(r.clients))
+	for _, client := range r.clients {
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// getOccupancy returns the current number of players, including players who
+// can still reconnect. Spectators are not counted.
 func (r *Room) getOccupancy() uint32 {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	return uint32(len(r.clients))
+	occupancy := uint32(0)
+	for _, client := range r.clients {
+		if !client.isSpectator {
+			occupancy++
+		}
+	}
+	for _, disconnected := range r.disconnected {
+		if !disconnected.client.isSpectator {
+			occupancy++
+		}
+	}
+	return occupancy
 }
@@ -0,0 +1,146 @@
+package room
+
+import (
+	"errors"
+	"net"
+	"server/internal/game"
+	"server/internal/game/entities"
+	"server/pb"
+	"testing"
+	"time"
+)
+
+// newTestRoom returns a room whose game is not running, so that clients can
+// be added and removed without a game loop.
+func newTestRoom() *Room {
+	clock := game.NewManualClock(time.UnixMilli(0))
+	g := game.NewGame(game.NewConfig(game.DEFAULT_REWIND_WINDOW, 0), 1, clock, nil)
+	return newRoom("room", g, 0, time.Hour)
+}
+
+func TestAdd(t *testing.T) {
+	tests := map[string]struct {
+		isConnected    bool // whether a previous connection is still open
+		isDisconnected bool // whether a previous connection was closed
+		wantResume     bool
+		wantErr        bool
+	}{
+		"new client":          {false, false, false, false},
+		"connected client":    {true, false, false, true},
+		"disconnected client": {false, true, true, false},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			r := newTestRoom()
+			previous := newClient("a", "a", false, nil)
+			if test.isConnected || test.isDisconnected {
+				r.add(previous)
+			}
+			if test.isDisconnected {
+				r.remove(previous)
+			}
+
+			isResuming, err := r.add(newClient("a", "a", false, nil))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("want error %v but got %v", test.wantErr, err)
+			}
+			if isResuming != test.wantResume {
+				t.Errorf("want %v but got %v", test.wantResume, isResuming)
+			}
+			if test.isConnected && r.clients["a"] != previous {
+				t.Errorf("want live client kept but got %v", r.clients["a"])
+			}
+		})
+	}
+}
+
+func TestHasSpace(t *testing.T) {
+	tests := map[string]struct {
+		isConnected    bool // whether a occupies the only slot
+		isDisconnected bool // whether a can still reconnect
+		isSpectator    bool // whether a is a spectator
+		clientId       string
+		asSpectator    bool // whether the joining client spectates
+		want           bool
+	}{
+		"empty room":                {false, false, false, "b", false, true},
+		"connected player":          {true, false, false, "b", false, false},
+		"disconnected player":       {true, true, false, "b", false, false},
+		"disconnected player again": {true, true, false, "a", false, true},
+		"disconnected spectator":    {true, true, true, "b", false, true},
+		"spectator in a full room":  {true, false, false, "b", true, true},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			r := newTestRoom()
+			r.maxPlayers = 1
+			client := newClient("a", "a", test.isSpectator, nil)
+			if test.isConnected {
+				r.add(client)
+			}
+			if test.isDisconnected {
+				r.remove(client)
+			}
+
+			got := r.HasSpace(test.clientId, test.asSpectator)
+			if got != test.want {
+				t.Errorf("want %v but got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestRemoveAfterStop(t *testing.T) {
+	r := newTestRoom()
+	r.stop()
+
+	client := newClient("a", "a", false, nil)
+	r.add(client)
+	r.remove(client)
+	if r.IsDisconnected("a") {
+		t.Errorf("want no reconnect timer after the room stopped")
+	}
+}
+
+func TestInitClientSpawnFailure(t *testing.T) {
+	mode, _ := game.NewGameMode(pb.GameMode_GAME_MODE_FREE_FOR_ALL, 0)
+	boundary, _ := game.NewBoundary(pb.Boundary_BOUNDARY_WRAP)
+	rules := game.Rules{Mode: mode, WorldSize: 1000, Boundary: boundary}
+
+	// The world is too small for a second player to spawn away from the first
+	clock := game.NewManualClock(time.UnixMilli(0))
+	config := game.NewConfig(game.DEFAULT_REWIND_WINDOW, 0).WithRules(rules)
+	r := newRoom("room", game.NewGame(config, 1, clock, nil), 0, time.Hour)
+	go r.broadcast()
+	t.Cleanup(r.stop)
+
+	_, errs := connectTestClient(t, r, "a", false)
+	if err := <-errs; err != nil {
+		t.Fatalf("want first player to join but got %v", err)
+	}
+
+	conn, errs := connectTestClient(t, r, "b", false)
+	if err := <-errs; !errors.Is(err, entities.ErrNoSpawnPoint) {
+		t.Fatalf("want %v but got %v", entities.ErrNoSpawnPoint, err)
+	}
+
+	// Messages sent before the client was discarded may still arrive
+	conn.SetReadDeadline(time.Now().Add(TEST_TIMEOUT))
+	for {
+		_, _, err := conn.ReadMessage()
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			t.Fatalf("want connection to be closed but got %v", err)
+		}
+		if err != nil {
+			break
+		}
+	}
+	if len(r.getClients()) != 1 {
+		t.Errorf("want 1 client but got %d", len(r.getClients()))
+	}
+	if r.IsDisconnected("b") {
+		t.Errorf("want no session to resume")
+	}
+}
@@ -0,0 +1,88 @@
+package session
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const INTERNAL_TOKEN_TTL = 10 * time.Second
+
+// InternalClaims are the contents of an internal token. The audience is the
+// address the request was sent to, and the token is only valid for a single
+// request with method and path.
+type InternalClaims struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	jwt.RegisteredClaims
+}
+
+// CreateInternalToken issues a short-lived JWT for a request between the
+// master and workers. issuer identifies the sender, and audience is the
+// address of the receiver.
+func CreateInternalToken(
+	issuer string,
+	audience string,
+	method string,
+	path string,
+	secret []byte,
+) (string, error) {
+	jti, err := newJti()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := InternalClaims{
+		Method: method,
+		Path:   path,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Issuer:    issuer,
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(INTERNAL_TOKEN_TTL)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(secret)
+}
+
+// ParseInternalToken verifies a token issued by CreateInternalToken for a
+// request with method and path to audience, and returns its claims.
+func ParseInternalToken(
+	token string,
+	audience string,
+	method string,
+	path string,
+	secret []byte,
+) (*InternalClaims, error) {
+	claims := InternalClaims{}
+	t, err := jwt.ParseWithClaims(
+		token,
+		&claims,
+		func(t *jwt.Token) (any, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method")
+			}
+			return secret, nil
+		},
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse token: %v", err)
+	}
+
+	if !t.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	if claims.Method != method || claims.Path != path {
+		return nil, fmt.Errorf("token was issued for %s %s", claims.Method, claims.Path)
+	}
+
+	return &claims, nil
+}
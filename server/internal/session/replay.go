@@ -0,0 +1,43 @@
+package session
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// A ReplayGuard remembers the IDs of tokens which have already been used,
+// until they expire.
+type ReplayGuard struct {
+	used map[string]time.Time // mapping of jti to expiry
+	mu   sync.Mutex
+}
+
+func NewReplayGuard() *ReplayGuard {
+	return &ReplayGuard{
+		used: map[string]time.Time{},
+		mu:   sync.Mutex{},
+	}
+}
+
+// Use marks the token with claims as used. It returns an error if the token
+// has already been used.
+func (g *ReplayGuard) Use(claims *jwt.RegisteredClaims) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	for jti, expiresAt := range g.used {
+		if now.After(expiresAt) {
+			delete(g.used, jti)
+		}
+	}
+
+	if _, found := g.used[claims.ID]; found {
+		return fmt.Errorf("token has already been used")
+	}
+	g.used[claims.ID] = claims.ExpiresAt.Time
+	return nil
+}
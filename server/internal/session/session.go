@@ -1,38 +1,87 @@
 package session
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// CreateToken issues a JWT.
+const (
+	TOKEN_TTL    = 60 * time.Second
+	JTI_BYTE_LEN = 16
+)
+
+// Claims are the contents of a join token. The audience is the worker host
+// that the client was assigned to.
+type Claims struct {
+	ClientId    string `json:"clientId"`
+	Username    string `json:"username"`
+	RoomId      string `json:"roomId"`
+	IsSpectator bool   `json:"spectator,omitempty"`
+	IsResume    bool   `json:"resume,omitempty"` // resumes a disconnected session
+	jwt.RegisteredClaims
+}
+
+// CreateToken issues a short-lived JWT which allows the client to connect to
+// roomId on host. Resume tokens can only reconnect a client which the room
+// still lists as disconnected.
 func CreateToken(
 	clientId string,
 	username string,
 	roomId string,
+	host string,
+	isSpectator bool,
+	isResume bool,
 	secret []byte,
 ) (string, error) {
-	claims := jwt.MapClaims{
-		"clientId": clientId,
-		"username": username,
-		"roomId":   roomId,
+	jti, err := newJti()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := Claims{
+		ClientId:    clientId,
+		Username:    username,
+		RoomId:      roomId,
+		IsSpectator: isSpectator,
+		IsResume:    isResume,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Audience:  jwt.ClaimStrings{host},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(TOKEN_TTL)),
+		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(secret)
 }
 
-// ParseToken returns the claims for a given tokenString.
+// ParseToken returns the claims for a given tokenString, if it is valid and
+// was issued for host.
 func ParseToken(
 	token string,
+	host string,
 	secret []byte,
-) (jwt.MapClaims, error) {
-	t, err := jwt.Parse(token, func(t *jwt.Token) (any, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method")
-		}
-		return secret, nil
-	})
+) (*Claims, error) {
+	claims := Claims{}
+	t, err := jwt.ParseWithClaims(
+		token,
+		&claims,
+		func(t *jwt.Token) (any, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method")
+			}
+			return secret, nil
+		},
+		jwt.WithAudience(host),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse token: %v", err)
 	}
@@ -40,6 +89,52 @@ func ParseToken(
 	if !t.Valid {
 		return nil, fmt.Errorf("invalid token")
 	}
+	if claims.ID == "" {
+		return nil, fmt.Errorf("token has no jti")
+	}
+
+	return &claims, nil
+}
 
-	return t.Claims.(jwt.MapClaims), nil
+// ParseResumableToken returns the claims for a given tokenString, which may
+// have been used already or have expired at most window ago. This allows a
+// client which lost its connection to be issued a new token for the same
+// session, while older tokens, e.g. from request logs, are rejected.
+func ParseResumableToken(
+	token string,
+	secret []byte,
+	window time.Duration,
+) (*Claims, error) {
+	claims := Claims{}
+	t, err := jwt.ParseWithClaims(
+		token,
+		&claims,
+		func(t *jwt.Token) (any, error) {
+			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method")
+			}
+			return secret, nil
+		},
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(window),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse token: %v", err)
+	}
+
+	if !t.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+
+	return &claims, nil
+}
+
+// newJti returns a random token ID.
+func newJti() (string, error) {
+	b := make([]byte, JTI_BYTE_LEN)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
@@ -0,0 +1,88 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var secret = []byte("secret")
+
+func TestParseToken(t *testing.T) {
+	token, err := CreateToken("1", "user", "room", "localhost:5174", false, false, secret)
+	if err != nil {
+		t.Fatalf("could not create token: %v", err)
+	}
+
+	tests := map[string]struct {
+		host    string
+		secret  []byte
+		wantErr bool
+	}{
+		"ParseToken on assigned host":  {"localhost:5174", secret, false},
+		"ParseToken on another host":   {"localhost:5175", secret, true},
+		"ParseToken with wrong secret": {"localhost:5174", []byte("wrong"), true},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			claims, err := ParseToken(token, test.host, test.secret)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("want error %v but got %v", test.wantErr, err)
+			}
+			if err == nil && claims.ClientId != "1" {
+				t.Errorf("want client ID 1 but got %v", claims.ClientId)
+			}
+		})
+	}
+}
+
+func TestReplayGuardUse(t *testing.T) {
+	guard := NewReplayGuard()
+	token, _ := CreateToken("1", "user", "room", "localhost:5174", false, false, secret)
+	claims, _ := ParseToken(token, "localhost:5174", secret)
+
+	if err := guard.Use(&claims.RegisteredClaims); err != nil {
+		t.Errorf("want first use to succeed but got %v", err)
+	}
+	if err := guard.Use(&claims.RegisteredClaims); err == nil {
+		t.Errorf("want second use to fail but got nil")
+	}
+}
+
+func TestParseResumableToken(t *testing.T) {
+	tests := map[string]struct {
+		issuedAgo time.Duration
+		secret    []byte
+		wantErr   bool
+	}{
+		"fresh token":                 {0, secret, false},
+		"token expired within window": {TOKEN_TTL + 10*time.Second, secret, false},
+		"token expired before window": {TOKEN_TTL + 60*time.Second, secret, true},
+		"token with wrong secret":     {0, []byte("wrong"), true},
+	}
+
+	for desc, test := range tests {
+		t.Run(desc, func(t *testing.T) {
+			issuedAt := time.Now().Add(-test.issuedAgo)
+			claims := Claims{
+				ClientId: "1",
+				RegisteredClaims: jwt.RegisteredClaims{
+					ID:        "jti",
+					IssuedAt:  jwt.NewNumericDate(issuedAt),
+					ExpiresAt: jwt.NewNumericDate(issuedAt.Add(TOKEN_TTL)),
+				},
+			}
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(test.secret)
+			if err != nil {
+				t.Fatalf("could not create token: %v", err)
+			}
+
+			_, err = ParseResumableToken(token, secret, 30*time.Second)
+			if (err != nil) != test.wantErr {
+				t.Errorf("want error %v but got %v", test.wantErr, err)
+			}
+		})
+	}
+}
@@ -25,6 +25,10 @@ type RegisterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
 	Port          string                 `protobuf:"bytes,2,opt,name=port,proto3" json:"port,omitempty"`
+	StartedAt     int64                  `protobuf:"varint,3,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	Capacity      uint32                 `protobuf:"varint,4,opt,name=capacity,proto3" json:"capacity,omitempty"`        // max number of clients, or 0 if unlimited
+	Weight        uint32                 `protobuf:"varint,5,opt,name=weight,proto3" json:"weight,omitempty"`            // relative share of clients, or 0 to use capacity
+	InternalPort  string                 `protobuf:"bytes,6,opt,name=internalPort,proto3" json:"internalPort,omitempty"` // port of the internal api, or empty if shared
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,9 +77,43 @@ func (x *RegisterRequest) GetPort() string {
 	return ""
 }
 
+func (x *RegisterRequest) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *RegisterRequest) GetCapacity() uint32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *RegisterRequest) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *RegisterRequest) GetInternalPort() string {
+	if x != nil {
+		return x.InternalPort
+	}
+	return ""
+}
+
 type CreateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RoomId        string                 `protobuf:"bytes,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	Mode          GameMode               `protobuf:"varint,2,opt,name=mode,proto3,enum=dogfight.GameMode" json:"mode,omitempty"`
+	ScoreLimit    uint32                 `protobuf:"varint,3,opt,name=scoreLimit,proto3" json:"scoreLimit,omitempty"`       // kills to win a round, or 0 for the mode's default
+	RoundDuration uint32                 `protobuf:"varint,4,opt,name=roundDuration,proto3" json:"roundDuration,omitempty"` // length of a round in seconds, or 0 if untimed
+	MaxPlayers    uint32                 `protobuf:"varint,5,opt,name=maxPlayers,proto3" json:"maxPlayers,omitempty"`       // or 0 if only limited by the master
+	WorldSize     uint32                 `protobuf:"varint,6,opt,name=worldSize,proto3" json:"worldSize,omitempty"`         // width and height of the world, or 0 for the default
+	Boundary      Boundary               `protobuf:"varint,7,opt,name=boundary,proto3,enum=dogfight.Boundary" json:"boundary,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -117,9 +155,52 @@ func (x *CreateRequest) GetRoomId() string {
 	return ""
 }
 
+func (x *CreateRequest) GetMode() GameMode {
+	if x != nil {
+		return x.Mode
+	}
+	return GameMode_GAME_MODE_FREE_FOR_ALL
+}
+
+func (x *CreateRequest) GetScoreLimit() uint32 {
+	if x != nil {
+		return x.ScoreLimit
+	}
+	return 0
+}
+
+func (x *CreateRequest) GetRoundDuration() uint32 {
+	if x != nil {
+		return x.RoundDuration
+	}
+	return 0
+}
+
+func (x *CreateRequest) GetMaxPlayers() uint32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *CreateRequest) GetWorldSize() uint32 {
+	if x != nil {
+		return x.WorldSize
+	}
+	return 0
+}
+
+func (x *CreateRequest) GetBoundary() Boundary {
+	if x != nil {
+		return x.Boundary
+	}
+	return Boundary_BOUNDARY_WRAP
+}
+
 type StatusResponse struct {
 	state         protoimpl.MessageState       `protogen:"open.v1"`
 	RoomStatuses  []*StatusResponse_RoomStatus `protobuf:"bytes,1,rep,name=roomStatuses,proto3" json:"roomStatuses,omitempty"`
+	ClosedRoomIds []string                     `protobuf:"bytes,2,rep,name=closedRoomIds,proto3" json:"closedRoomIds,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -161,6 +242,13 @@ func (x *StatusResponse) GetRoomStatuses() []*StatusResponse_RoomStatus {
 	return nil
 }
 
+func (x *StatusResponse) GetClosedRoomIds() []string {
+	if x != nil {
+		return x.ClosedRoomIds
+	}
+	return nil
+}
+
 type StatusResponse_RoomStatus struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RoomId        string                 `protobuf:"bytes,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
@@ -217,14 +305,29 @@ var File_balancer_proto protoreflect.FileDescriptor
 
 const file_balancer_proto_rawDesc = "" +
 	"\n" +
-	"\x0ebalancer.proto\x12\bdogfight\"9\n" +
+	"\x0ebalancer.proto\x12\bdogfight\x1a\vevent.proto\"\xaf\x01\n" +
 	"\x0fRegisterRequest\x12\x12\n" +
 	"\x04host\x18\x01 \x01(\tR\x04host\x12\x12\n" +
-	"\x04port\x18\x02 \x01(\tR\x04port\"'\n" +
+	"\x04port\x18\x02 \x01(\tR\x04port\x12\x1c\n" +
+	"\tstartedAt\x18\x03 \x01(\x03R\tstartedAt\x12\x1a\n" +
+	"\bcapacity\x18\x04 \x01(\rR\bcapacity\x12\x16\n" +
+	"\x06weight\x18\x05 \x01(\rR\x06weight\x12\"\n" +
+	"\finternalPort\x18\x06 \x01(\tR\finternalPort\"\x83\x02\n" +
 	"\rCreateRequest\x12\x16\n" +
-	"\x06roomId\x18\x01 \x01(\tR\x06roomId\"\x9d\x01\n" +
+	"\x06roomId\x18\x01 \x01(\tR\x06roomId\x12&\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x12.dogfight.GameModeR\x04mode\x12\x1e\n" +
+	"\n" +
+	"scoreLimit\x18\x03 \x01(\rR\n" +
+	"scoreLimit\x12$\n" +
+	"\rroundDuration\x18\x04 \x01(\rR\rroundDuration\x12\x1e\n" +
+	"\n" +
+	"maxPlayers\x18\x05 \x01(\rR\n" +
+	"maxPlayers\x12\x1c\n" +
+	"\tworldSize\x18\x06 \x01(\rR\tworldSize\x12.\n" +
+	"\bboundary\x18\a \x01(\x0e2\x12.dogfight.BoundaryR\bboundary\"\xc3\x01\n" +
 	"\x0eStatusResponse\x12G\n" +
-	"\froomStatuses\x18\x01 \x03(\v2#.dogfight.StatusResponse.RoomStatusR\froomStatuses\x1aB\n" +
+	"\froomStatuses\x18\x01 \x03(\v2#.dogfight.StatusResponse.RoomStatusR\froomStatuses\x12$\n" +
+	"\rclosedRoomIds\x18\x02 \x03(\tR\rclosedRoomIds\x1aB\n" +
 	"\n" +
 	"RoomStatus\x12\x16\n" +
 	"\x06roomId\x18\x01 \x01(\tR\x06roomId\x12\x1c\n" +
@@ -248,14 +351,18 @@ var file_balancer_proto_goTypes = []any{
 	(*CreateRequest)(nil),             // 1: dogfight.CreateRequest
 	(*StatusResponse)(nil),            // 2: dogfight.StatusResponse
 	(*StatusResponse_RoomStatus)(nil), // 3: dogfight.StatusResponse.RoomStatus
+	(GameMode)(0),                     // 4: dogfight.GameMode
+	(Boundary)(0),                     // 5: dogfight.Boundary
 }
 var file_balancer_proto_depIdxs = []int32{
-	3, // 0: dogfight.StatusResponse.roomStatuses:type_name -> dogfight.StatusResponse.RoomStatus
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	4, // 0: dogfight.CreateRequest.mode:type_name -> dogfight.GameMode
+	5, // 1: dogfight.CreateRequest.boundary:type_name -> dogfight.Boundary
+	3, // 2: dogfight.StatusResponse.roomStatuses:type_name -> dogfight.StatusResponse.RoomStatus
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_balancer_proto_init() }
@@ -263,6 +370,7 @@ func file_balancer_proto_init() {
 	if File_balancer_proto != nil {
 		return
 	}
+	file_event_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
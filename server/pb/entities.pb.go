@@ -21,6 +21,64 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DamageCause int32
+
+const (
+	DamageCause_DAMAGE_CAUSE_UNKNOWN    DamageCause = 0
+	DamageCause_DAMAGE_CAUSE_PROJECTILE DamageCause = 1
+	DamageCause_DAMAGE_CAUSE_WIDE_BEAM  DamageCause = 2
+	DamageCause_DAMAGE_CAUSE_ASTEROID   DamageCause = 3 // ramming an asteroid
+	DamageCause_DAMAGE_CAUSE_PLAYER     DamageCause = 4 // ramming another player
+	DamageCause_DAMAGE_CAUSE_ZONE       DamageCause = 5 // staying outside the safe zone
+)
+
+// Enum value maps for DamageCause.
+var (
+	DamageCause_name = map[int32]string{
+		0: "DAMAGE_CAUSE_UNKNOWN",
+		1: "DAMAGE_CAUSE_PROJECTILE",
+		2: "DAMAGE_CAUSE_WIDE_BEAM",
+		3: "DAMAGE_CAUSE_ASTEROID",
+		4: "DAMAGE_CAUSE_PLAYER",
+		5: "DAMAGE_CAUSE_ZONE",
+	}
+	DamageCause_value = map[string]int32{
+		"DAMAGE_CAUSE_UNKNOWN":    0,
+		"DAMAGE_CAUSE_PROJECTILE": 1,
+		"DAMAGE_CAUSE_WIDE_BEAM":  2,
+		"DAMAGE_CAUSE_ASTEROID":   3,
+		"DAMAGE_CAUSE_PLAYER":     4,
+		"DAMAGE_CAUSE_ZONE":       5,
+	}
+)
+
+func (x DamageCause) Enum() *DamageCause {
+	p := new(DamageCause)
+	*p = x
+	return p
+}
+
+func (x DamageCause) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DamageCause) Descriptor() protoreflect.EnumDescriptor {
+	return file_entities_proto_enumTypes[0].Descriptor()
+}
+
+func (DamageCause) Type() protoreflect.EnumType {
+	return &file_entities_proto_enumTypes[0]
+}
+
+func (x DamageCause) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DamageCause.Descriptor instead.
+func (DamageCause) EnumDescriptor() ([]byte, []int) {
+	return file_entities_proto_rawDescGZIP(), []int{0}
+}
+
 type EntityType int32
 
 const (
@@ -63,11 +121,11 @@ func (x EntityType) String() string {
 }
 
 func (EntityType) Descriptor() protoreflect.EnumDescriptor {
-	return file_entities_proto_enumTypes[0].Descriptor()
+	return file_entities_proto_enumTypes[1].Descriptor()
 }
 
 func (EntityType) Type() protoreflect.EnumType {
-	return &file_entities_proto_enumTypes[0]
+	return &file_entities_proto_enumTypes[1]
 }
 
 func (x EntityType) Number() protoreflect.EnumNumber {
@@ -76,7 +134,7 @@ func (x EntityType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use EntityType.Descriptor instead.
 func (EntityType) EnumDescriptor() ([]byte, []int) {
-	return file_entities_proto_rawDescGZIP(), []int{0}
+	return file_entities_proto_rawDescGZIP(), []int{1}
 }
 
 type EntityData struct {
@@ -233,6 +291,242 @@ func (*EntityData_PowerupData_) isEntityData_Data() {}
 
 func (*EntityData_ProjectileData_) isEntityData_Data() {}
 
+type AbilityTimer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ability       uint32                 `protobuf:"varint,1,opt,name=ability,proto3" json:"ability,omitempty"`
+	Remaining     uint32                 `protobuf:"varint,2,opt,name=remaining,proto3" json:"remaining,omitempty"` // ticks until the ability expires
+	Stacks        uint32                 `protobuf:"varint,3,opt,name=stacks,proto3" json:"stacks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbilityTimer) Reset() {
+	*x = AbilityTimer{}
+	mi := &file_entities_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbilityTimer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbilityTimer) ProtoMessage() {}
+
+func (x *AbilityTimer) ProtoReflect() protoreflect.Message {
+	mi := &file_entities_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbilityTimer.ProtoReflect.Descriptor instead.
+func (*AbilityTimer) Descriptor() ([]byte, []int) {
+	return file_entities_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AbilityTimer) GetAbility() uint32 {
+	if x != nil {
+		return x.Ability
+	}
+	return 0
+}
+
+func (x *AbilityTimer) GetRemaining() uint32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *AbilityTimer) GetStacks() uint32 {
+	if x != nil {
+		return x.Stacks
+	}
+	return 0
+}
+
+type AbilityTimers struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timers        []*AbilityTimer        `protobuf:"bytes,1,rep,name=timers,proto3" json:"timers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbilityTimers) Reset() {
+	*x = AbilityTimers{}
+	mi := &file_entities_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbilityTimers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbilityTimers) ProtoMessage() {}
+
+func (x *AbilityTimers) ProtoReflect() protoreflect.Message {
+	mi := &file_entities_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbilityTimers.ProtoReflect.Descriptor instead.
+func (*AbilityTimers) Descriptor() ([]byte, []int) {
+	return file_entities_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AbilityTimers) GetTimers() []*AbilityTimer {
+	if x != nil {
+		return x.Timers
+	}
+	return nil
+}
+
+type EntityDelta struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Position       *Vector                `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	Velocity       *Vector                `protobuf:"bytes,3,opt,name=velocity,proto3" json:"velocity,omitempty"`
+	Rotation       *float64               `protobuf:"fixed64,4,opt,name=rotation,proto3,oneof" json:"rotation,omitempty"`
+	Score          *uint32                `protobuf:"varint,5,opt,name=score,proto3,oneof" json:"score,omitempty"`
+	Flags          *uint32                `protobuf:"varint,6,opt,name=flags,proto3,oneof" json:"flags,omitempty"`
+	Abilities      *AbilityTimers         `protobuf:"bytes,7,opt,name=abilities,proto3" json:"abilities,omitempty"` // only set if abilities were picked up or expired
+	Health         *uint32                `protobuf:"varint,8,opt,name=health,proto3,oneof" json:"health,omitempty"`
+	IsInvulnerable *bool                  `protobuf:"varint,9,opt,name=isInvulnerable,proto3,oneof" json:"isInvulnerable,omitempty"`
+	Heat           *uint32                `protobuf:"varint,10,opt,name=heat,proto3,oneof" json:"heat,omitempty"`
+	IsOverheated   *bool                  `protobuf:"varint,11,opt,name=isOverheated,proto3,oneof" json:"isOverheated,omitempty"`
+	FireInterval   *uint32                `protobuf:"varint,12,opt,name=fireInterval,proto3,oneof" json:"fireInterval,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EntityDelta) Reset() {
+	*x = EntityDelta{}
+	mi := &file_entities_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityDelta) ProtoMessage() {}
+
+func (x *EntityDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_entities_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityDelta.ProtoReflect.Descriptor instead.
+func (*EntityDelta) Descriptor() ([]byte, []int) {
+	return file_entities_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EntityDelta) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EntityDelta) GetPosition() *Vector {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *EntityDelta) GetVelocity() *Vector {
+	if x != nil {
+		return x.Velocity
+	}
+	return nil
+}
+
+func (x *EntityDelta) GetRotation() float64 {
+	if x != nil && x.Rotation != nil {
+		return *x.Rotation
+	}
+	return 0
+}
+
+func (x *EntityDelta) GetScore() uint32 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+func (x *EntityDelta) GetFlags() uint32 {
+	if x != nil && x.Flags != nil {
+		return *x.Flags
+	}
+	return 0
+}
+
+func (x *EntityDelta) GetAbilities() *AbilityTimers {
+	if x != nil {
+		return x.Abilities
+	}
+	return nil
+}
+
+func (x *EntityDelta) GetHealth() uint32 {
+	if x != nil && x.Health != nil {
+		return *x.Health
+	}
+	return 0
+}
+
+func (x *EntityDelta) GetIsInvulnerable() bool {
+	if x != nil && x.IsInvulnerable != nil {
+		return *x.IsInvulnerable
+	}
+	return false
+}
+
+func (x *EntityDelta) GetHeat() uint32 {
+	if x != nil && x.Heat != nil {
+		return *x.Heat
+	}
+	return 0
+}
+
+func (x *EntityDelta) GetIsOverheated() bool {
+	if x != nil && x.IsOverheated != nil {
+		return *x.IsOverheated
+	}
+	return false
+}
+
+func (x *EntityDelta) GetFireInterval() uint32 {
+	if x != nil && x.FireInterval != nil {
+		return *x.FireInterval
+	}
+	return 0
+}
+
 type EntityData_AsteroidData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Points        []*Vector              `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
@@ -242,7 +536,7 @@ type EntityData_AsteroidData struct {
 
 func (x *EntityData_AsteroidData) Reset() {
 	*x = EntityData_AsteroidData{}
-	mi := &file_entities_proto_msgTypes[1]
+	mi := &file_entities_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -254,7 +548,7 @@ func (x *EntityData_AsteroidData) String() string {
 func (*EntityData_AsteroidData) ProtoMessage() {}
 
 func (x *EntityData_AsteroidData) ProtoReflect() protoreflect.Message {
-	mi := &file_entities_proto_msgTypes[1]
+	mi := &file_entities_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -278,17 +572,24 @@ func (x *EntityData_AsteroidData) GetPoints() []*Vector {
 }
 
 type EntityData_PlayerData struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Score         uint32                 `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
-	Flags         uint32                 `protobuf:"varint,3,opt,name=flags,proto3" json:"flags,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Username       string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Score          uint32                 `protobuf:"varint,2,opt,name=score,proto3" json:"score,omitempty"`
+	Flags          uint32                 `protobuf:"varint,3,opt,name=flags,proto3" json:"flags,omitempty"`
+	Team           uint32                 `protobuf:"varint,4,opt,name=team,proto3" json:"team,omitempty"`          // or 0 if the game mode has no teams
+	Abilities      []*AbilityTimer        `protobuf:"bytes,5,rep,name=abilities,proto3" json:"abilities,omitempty"` // active abilities
+	Health         uint32                 `protobuf:"varint,6,opt,name=health,proto3" json:"health,omitempty"`
+	IsInvulnerable bool                   `protobuf:"varint,7,opt,name=isInvulnerable,proto3" json:"isInvulnerable,omitempty"` // whether the player cannot take damage
+	Heat           uint32                 `protobuf:"varint,8,opt,name=heat,proto3" json:"heat,omitempty"`                     // weapon heat, which builds up with each shot
+	IsOverheated   bool                   `protobuf:"varint,9,opt,name=isOverheated,proto3" json:"isOverheated,omitempty"`     // whether the weapon is cooling down to 0 heat
+	FireInterval   uint32                 `protobuf:"varint,10,opt,name=fireInterval,proto3" json:"fireInterval,omitempty"`    // min ticks between shots
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *EntityData_PlayerData) Reset() {
 	*x = EntityData_PlayerData{}
-	mi := &file_entities_proto_msgTypes[2]
+	mi := &file_entities_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -300,7 +601,7 @@ func (x *EntityData_PlayerData) String() string {
 func (*EntityData_PlayerData) ProtoMessage() {}
 
 func (x *EntityData_PlayerData) ProtoReflect() protoreflect.Message {
-	mi := &file_entities_proto_msgTypes[2]
+	mi := &file_entities_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -337,6 +638,55 @@ func (x *EntityData_PlayerData) GetFlags() uint32 {
 	return 0
 }
 
+func (x *EntityData_PlayerData) GetTeam() uint32 {
+	if x != nil {
+		return x.Team
+	}
+	return 0
+}
+
+func (x *EntityData_PlayerData) GetAbilities() []*AbilityTimer {
+	if x != nil {
+		return x.Abilities
+	}
+	return nil
+}
+
+func (x *EntityData_PlayerData) GetHealth() uint32 {
+	if x != nil {
+		return x.Health
+	}
+	return 0
+}
+
+func (x *EntityData_PlayerData) GetIsInvulnerable() bool {
+	if x != nil {
+		return x.IsInvulnerable
+	}
+	return false
+}
+
+func (x *EntityData_PlayerData) GetHeat() uint32 {
+	if x != nil {
+		return x.Heat
+	}
+	return 0
+}
+
+func (x *EntityData_PlayerData) GetIsOverheated() bool {
+	if x != nil {
+		return x.IsOverheated
+	}
+	return false
+}
+
+func (x *EntityData_PlayerData) GetFireInterval() uint32 {
+	if x != nil {
+		return x.FireInterval
+	}
+	return 0
+}
+
 type EntityData_PowerupData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Ability       uint32                 `protobuf:"varint,1,opt,name=ability,proto3" json:"ability,omitempty"`
@@ -346,7 +696,7 @@ type EntityData_PowerupData struct {
 
 func (x *EntityData_PowerupData) Reset() {
 	*x = EntityData_PowerupData{}
-	mi := &file_entities_proto_msgTypes[3]
+	mi := &file_entities_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -358,7 +708,7 @@ func (x *EntityData_PowerupData) String() string {
 func (*EntityData_PowerupData) ProtoMessage() {}
 
 func (x *EntityData_PowerupData) ProtoReflect() protoreflect.Message {
-	mi := &file_entities_proto_msgTypes[3]
+	mi := &file_entities_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,7 +741,7 @@ type EntityData_ProjectileData struct {
 
 func (x *EntityData_ProjectileData) Reset() {
 	*x = EntityData_ProjectileData{}
-	mi := &file_entities_proto_msgTypes[4]
+	mi := &file_entities_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -403,7 +753,7 @@ func (x *EntityData_ProjectileData) String() string {
 func (*EntityData_ProjectileData) ProtoMessage() {}
 
 func (x *EntityData_ProjectileData) ProtoReflect() protoreflect.Message {
-	mi := &file_entities_proto_msgTypes[4]
+	mi := &file_entities_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -437,7 +787,7 @@ var File_entities_proto protoreflect.FileDescriptor
 
 const file_entities_proto_rawDesc = "" +
 	"\n" +
-	"\x0eentities.proto\x12\bdogfight\x1a\fvector.proto\"\xe4\x05\n" +
+	"\x0eentities.proto\x12\bdogfight\x1a\fvector.proto\"\xcb\a\n" +
 	"\n" +
 	"EntityData\x12(\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x14.dogfight.EntityTypeR\x04type\x12\x0e\n" +
@@ -452,18 +802,61 @@ const file_entities_proto_rawDesc = "" +
 	"\vpowerupData\x18\b \x01(\v2 .dogfight.EntityData.PowerupDataH\x00R\vpowerupData\x12M\n" +
 	"\x0eprojectileData\x18\t \x01(\v2#.dogfight.EntityData.ProjectileDataH\x00R\x0eprojectileData\x1a8\n" +
 	"\fAsteroidData\x12(\n" +
-	"\x06points\x18\x01 \x03(\v2\x10.dogfight.VectorR\x06points\x1aT\n" +
+	"\x06points\x18\x01 \x03(\v2\x10.dogfight.VectorR\x06points\x1a\xba\x02\n" +
 	"\n" +
 	"PlayerData\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
 	"\x05score\x18\x02 \x01(\rR\x05score\x12\x14\n" +
-	"\x05flags\x18\x03 \x01(\rR\x05flags\x1a'\n" +
+	"\x05flags\x18\x03 \x01(\rR\x05flags\x12\x12\n" +
+	"\x04team\x18\x04 \x01(\rR\x04team\x124\n" +
+	"\tabilities\x18\x05 \x03(\v2\x16.dogfight.AbilityTimerR\tabilities\x12\x16\n" +
+	"\x06health\x18\x06 \x01(\rR\x06health\x12&\n" +
+	"\x0eisInvulnerable\x18\a \x01(\bR\x0eisInvulnerable\x12\x12\n" +
+	"\x04heat\x18\b \x01(\rR\x04heat\x12\"\n" +
+	"\fisOverheated\x18\t \x01(\bR\fisOverheated\x12\"\n" +
+	"\ffireInterval\x18\n" +
+	" \x01(\rR\ffireInterval\x1a'\n" +
 	"\vPowerupData\x12\x18\n" +
 	"\aability\x18\x01 \x01(\rR\aability\x1aB\n" +
 	"\x0eProjectileData\x12\x14\n" +
 	"\x05flags\x18\x01 \x01(\rR\x05flags\x12\x1a\n" +
 	"\blifetime\x18\x02 \x01(\x05R\blifetimeB\x06\n" +
-	"\x04data*\xa2\x01\n" +
+	"\x04data\"^\n" +
+	"\fAbilityTimer\x12\x18\n" +
+	"\aability\x18\x01 \x01(\rR\aability\x12\x1c\n" +
+	"\tremaining\x18\x02 \x01(\rR\tremaining\x12\x16\n" +
+	"\x06stacks\x18\x03 \x01(\rR\x06stacks\"?\n" +
+	"\rAbilityTimers\x12.\n" +
+	"\x06timers\x18\x01 \x03(\v2\x16.dogfight.AbilityTimerR\x06timers\"\xa6\x04\n" +
+	"\vEntityDelta\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\bposition\x18\x02 \x01(\v2\x10.dogfight.VectorR\bposition\x12,\n" +
+	"\bvelocity\x18\x03 \x01(\v2\x10.dogfight.VectorR\bvelocity\x12\x1f\n" +
+	"\brotation\x18\x04 \x01(\x01H\x00R\brotation\x88\x01\x01\x12\x19\n" +
+	"\x05score\x18\x05 \x01(\rH\x01R\x05score\x88\x01\x01\x12\x19\n" +
+	"\x05flags\x18\x06 \x01(\rH\x02R\x05flags\x88\x01\x01\x125\n" +
+	"\tabilities\x18\a \x01(\v2\x17.dogfight.AbilityTimersR\tabilities\x12\x1b\n" +
+	"\x06health\x18\b \x01(\rH\x03R\x06health\x88\x01\x01\x12+\n" +
+	"\x0eisInvulnerable\x18\t \x01(\bH\x04R\x0eisInvulnerable\x88\x01\x01\x12\x17\n" +
+	"\x04heat\x18\n" +
+	" \x01(\rH\x05R\x04heat\x88\x01\x01\x12'\n" +
+	"\fisOverheated\x18\v \x01(\bH\x06R\fisOverheated\x88\x01\x01\x12'\n" +
+	"\ffireInterval\x18\f \x01(\rH\aR\ffireInterval\x88\x01\x01B\v\n" +
+	"\t_rotationB\b\n" +
+	"\x06_scoreB\b\n" +
+	"\x06_flagsB\t\n" +
+	"\a_healthB\x11\n" +
+	"\x0f_isInvulnerableB\a\n" +
+	"\x05_heatB\x0f\n" +
+	"\r_isOverheatedB\x0f\n" +
+	"\r_fireInterval*\xab\x01\n" +
+	"\vDamageCause\x12\x18\n" +
+	"\x14DAMAGE_CAUSE_UNKNOWN\x10\x00\x12\x1b\n" +
+	"\x17DAMAGE_CAUSE_PROJECTILE\x10\x01\x12\x1a\n" +
+	"\x16DAMAGE_CAUSE_WIDE_BEAM\x10\x02\x12\x19\n" +
+	"\x15DAMAGE_CAUSE_ASTEROID\x10\x03\x12\x17\n" +
+	"\x13DAMAGE_CAUSE_PLAYER\x10\x04\x12\x15\n" +
+	"\x11DAMAGE_CAUSE_ZONE\x10\x05*\xa2\x01\n" +
 	"\n" +
 	"EntityType\x12\x17\n" +
 	"\x13ENTITY_TYPE_UNKNOWN\x10\x00\x12\x18\n" +
@@ -485,31 +878,40 @@ func file_entities_proto_rawDescGZIP() []byte {
 	return file_entities_proto_rawDescData
 }
 
-var file_entities_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_entities_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_entities_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_entities_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_entities_proto_goTypes = []any{
-	(EntityType)(0),                   // 0: dogfight.EntityType
-	(*EntityData)(nil),                // 1: dogfight.EntityData
-	(*EntityData_AsteroidData)(nil),   // 2: dogfight.EntityData.AsteroidData
-	(*EntityData_PlayerData)(nil),     // 3: dogfight.EntityData.PlayerData
-	(*EntityData_PowerupData)(nil),    // 4: dogfight.EntityData.PowerupData
-	(*EntityData_ProjectileData)(nil), // 5: dogfight.EntityData.ProjectileData
-	(*Vector)(nil),                    // 6: dogfight.Vector
+	(DamageCause)(0),                  // 0: dogfight.DamageCause
+	(EntityType)(0),                   // 1: dogfight.EntityType
+	(*EntityData)(nil),                // 2: dogfight.EntityData
+	(*AbilityTimer)(nil),              // 3: dogfight.AbilityTimer
+	(*AbilityTimers)(nil),             // 4: dogfight.AbilityTimers
+	(*EntityDelta)(nil),               // 5: dogfight.EntityDelta
+	(*EntityData_AsteroidData)(nil),   // 6: dogfight.EntityData.AsteroidData
+	(*EntityData_PlayerData)(nil),     // 7: dogfight.EntityData.PlayerData
+	(*EntityData_PowerupData)(nil),    // 8: dogfight.EntityData.PowerupData
+	(*EntityData_ProjectileData)(nil), // 9: dogfight.EntityData.ProjectileData
+	(*Vector)(nil),                    // 10: dogfight.Vector
 }
 var file_entities_proto_depIdxs = []int32{
-	0, // 0: dogfight.EntityData.type:type_name -> dogfight.EntityType
-	6, // 1: dogfight.EntityData.position:type_name -> dogfight.Vector
-	6, // 2: dogfight.EntityData.velocity:type_name -> dogfight.Vector
-	2, // 3: dogfight.EntityData.asteroidData:type_name -> dogfight.EntityData.AsteroidData
-	3, // 4: dogfight.EntityData.playerData:type_name -> dogfight.EntityData.PlayerData
-	4, // 5: dogfight.EntityData.powerupData:type_name -> dogfight.EntityData.PowerupData
-	5, // 6: dogfight.EntityData.projectileData:type_name -> dogfight.EntityData.ProjectileData
-	6, // 7: dogfight.EntityData.AsteroidData.points:type_name -> dogfight.Vector
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	1,  // 0: dogfight.EntityData.type:type_name -> dogfight.EntityType
+	10, // 1: dogfight.EntityData.position:type_name -> dogfight.Vector
+	10, // 2: dogfight.EntityData.velocity:type_name -> dogfight.Vector
+	6,  // 3: dogfight.EntityData.asteroidData:type_name -> dogfight.EntityData.AsteroidData
+	7,  // 4: dogfight.EntityData.playerData:type_name -> dogfight.EntityData.PlayerData
+	8,  // 5: dogfight.EntityData.powerupData:type_name -> dogfight.EntityData.PowerupData
+	9,  // 6: dogfight.EntityData.projectileData:type_name -> dogfight.EntityData.ProjectileData
+	3,  // 7: dogfight.AbilityTimers.timers:type_name -> dogfight.AbilityTimer
+	10, // 8: dogfight.EntityDelta.position:type_name -> dogfight.Vector
+	10, // 9: dogfight.EntityDelta.velocity:type_name -> dogfight.Vector
+	4,  // 10: dogfight.EntityDelta.abilities:type_name -> dogfight.AbilityTimers
+	10, // 11: dogfight.EntityData.AsteroidData.points:type_name -> dogfight.Vector
+	3,  // 12: dogfight.EntityData.PlayerData.abilities:type_name -> dogfight.AbilityTimer
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_entities_proto_init() }
@@ -524,13 +926,14 @@ func file_entities_proto_init() {
 		(*EntityData_PowerupData_)(nil),
 		(*EntityData_ProjectileData_)(nil),
 	}
+	file_entities_proto_msgTypes[3].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_entities_proto_rawDesc), len(file_entities_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
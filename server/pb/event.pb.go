@@ -21,6 +21,104 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type GameMode int32
+
+const (
+	GameMode_GAME_MODE_FREE_FOR_ALL    GameMode = 0
+	GameMode_GAME_MODE_TEAM_DEATHMATCH GameMode = 1
+	GameMode_GAME_MODE_FIRST_TO_N      GameMode = 2
+)
+
+// Enum value maps for GameMode.
+var (
+	GameMode_name = map[int32]string{
+		0: "GAME_MODE_FREE_FOR_ALL",
+		1: "GAME_MODE_TEAM_DEATHMATCH",
+		2: "GAME_MODE_FIRST_TO_N",
+	}
+	GameMode_value = map[string]int32{
+		"GAME_MODE_FREE_FOR_ALL":    0,
+		"GAME_MODE_TEAM_DEATHMATCH": 1,
+		"GAME_MODE_FIRST_TO_N":      2,
+	}
+)
+
+func (x GameMode) Enum() *GameMode {
+	p := new(GameMode)
+	*p = x
+	return p
+}
+
+func (x GameMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_event_proto_enumTypes[0].Descriptor()
+}
+
+func (GameMode) Type() protoreflect.EnumType {
+	return &file_event_proto_enumTypes[0]
+}
+
+func (x GameMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameMode.Descriptor instead.
+func (GameMode) EnumDescriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{0}
+}
+
+type Boundary int32
+
+const (
+	Boundary_BOUNDARY_WRAP           Boundary = 0
+	Boundary_BOUNDARY_BOUNCE         Boundary = 1
+	Boundary_BOUNDARY_SHRINKING_ZONE Boundary = 2
+)
+
+// Enum value maps for Boundary.
+var (
+	Boundary_name = map[int32]string{
+		0: "BOUNDARY_WRAP",
+		1: "BOUNDARY_BOUNCE",
+		2: "BOUNDARY_SHRINKING_ZONE",
+	}
+	Boundary_value = map[string]int32{
+		"BOUNDARY_WRAP":           0,
+		"BOUNDARY_BOUNCE":         1,
+		"BOUNDARY_SHRINKING_ZONE": 2,
+	}
+)
+
+func (x Boundary) Enum() *Boundary {
+	p := new(Boundary)
+	*p = x
+	return p
+}
+
+func (x Boundary) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Boundary) Descriptor() protoreflect.EnumDescriptor {
+	return file_event_proto_enumTypes[1].Descriptor()
+}
+
+func (Boundary) Type() protoreflect.EnumType {
+	return &file_event_proto_enumTypes[1]
+}
+
+func (x Boundary) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Boundary.Descriptor instead.
+func (Boundary) EnumDescriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{1}
+}
+
 type EventType int32
 
 const (
@@ -31,18 +129,26 @@ const (
 	EventType_EVENT_TYPE_INPUT    EventType = 4
 	EventType_EVENT_TYPE_SNAPSHOT EventType = 5
 	EventType_EVENT_TYPE_DELTA    EventType = 6
+	EventType_EVENT_TYPE_SPECTATE EventType = 7
+	EventType_EVENT_TYPE_ROUND    EventType = 8
+	EventType_EVENT_TYPE_DEATH    EventType = 9
+	EventType_EVENT_TYPE_RESUME   EventType = 10
 )
 
 // Enum value maps for EventType.
 var (
 	EventType_name = map[int32]string{
-		0: "EVENT_TYPE_UNKNOWN",
-		1: "EVENT_TYPE_JOIN",
-		2: "EVENT_TYPE_QUIT",
-		3: "EVENT_TYPE_RESPAWN",
-		4: "EVENT_TYPE_INPUT",
-		5: "EVENT_TYPE_SNAPSHOT",
-		6: "EVENT_TYPE_DELTA",
+		0:  "EVENT_TYPE_UNKNOWN",
+		1:  "EVENT_TYPE_JOIN",
+		2:  "EVENT_TYPE_QUIT",
+		3:  "EVENT_TYPE_RESPAWN",
+		4:  "EVENT_TYPE_INPUT",
+		5:  "EVENT_TYPE_SNAPSHOT",
+		6:  "EVENT_TYPE_DELTA",
+		7:  "EVENT_TYPE_SPECTATE",
+		8:  "EVENT_TYPE_ROUND",
+		9:  "EVENT_TYPE_DEATH",
+		10: "EVENT_TYPE_RESUME",
 	}
 	EventType_value = map[string]int32{
 		"EVENT_TYPE_UNKNOWN":  0,
@@ -52,6 +158,10 @@ var (
 		"EVENT_TYPE_INPUT":    4,
 		"EVENT_TYPE_SNAPSHOT": 5,
 		"EVENT_TYPE_DELTA":    6,
+		"EVENT_TYPE_SPECTATE": 7,
+		"EVENT_TYPE_ROUND":    8,
+		"EVENT_TYPE_DEATH":    9,
+		"EVENT_TYPE_RESUME":   10,
 	}
 )
 
@@ -66,11 +176,11 @@ func (x EventType) String() string {
 }
 
 func (EventType) Descriptor() protoreflect.EnumDescriptor {
-	return file_event_proto_enumTypes[0].Descriptor()
+	return file_event_proto_enumTypes[2].Descriptor()
 }
 
 func (EventType) Type() protoreflect.EnumType {
-	return &file_event_proto_enumTypes[0]
+	return &file_event_proto_enumTypes[2]
 }
 
 func (x EventType) Number() protoreflect.EnumNumber {
@@ -79,7 +189,7 @@ func (x EventType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use EventType.Descriptor instead.
 func (EventType) EnumDescriptor() ([]byte, []int) {
-	return file_event_proto_rawDescGZIP(), []int{0}
+	return file_event_proto_rawDescGZIP(), []int{2}
 }
 
 type Event struct {
@@ -93,6 +203,10 @@ type Event struct {
 	//	*Event_InputEventData_
 	//	*Event_SnapshotEventData_
 	//	*Event_DeltaEventData_
+	//	*Event_SpectateEventData_
+	//	*Event_RoundEventData_
+	//	*Event_DeathEventData_
+	//	*Event_ResumeEventData_
 	Data          isEvent_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -196,6 +310,42 @@ func (x *Event) GetDeltaEventData() *Event_DeltaEventData {
 	return nil
 }
 
+func (x *Event) GetSpectateEventData() *Event_SpectateEventData {
+	if x != nil {
+		if x, ok := x.Data.(*Event_SpectateEventData_); ok {
+			return x.SpectateEventData
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetRoundEventData() *Event_RoundEventData {
+	if x != nil {
+		if x, ok := x.Data.(*Event_RoundEventData_); ok {
+			return x.RoundEventData
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetDeathEventData() *Event_DeathEventData {
+	if x != nil {
+		if x, ok := x.Data.(*Event_DeathEventData_); ok {
+			return x.DeathEventData
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetResumeEventData() *Event_ResumeEventData {
+	if x != nil {
+		if x, ok := x.Data.(*Event_ResumeEventData_); ok {
+			return x.ResumeEventData
+		}
+	}
+	return nil
+}
+
 type isEvent_Data interface {
 	isEvent_Data()
 }
@@ -224,6 +374,22 @@ type Event_DeltaEventData_ struct {
 	DeltaEventData *Event_DeltaEventData `protobuf:"bytes,7,opt,name=deltaEventData,proto3,oneof"`
 }
 
+type Event_SpectateEventData_ struct {
+	SpectateEventData *Event_SpectateEventData `protobuf:"bytes,8,opt,name=spectateEventData,proto3,oneof"`
+}
+
+type Event_RoundEventData_ struct {
+	RoundEventData *Event_RoundEventData `protobuf:"bytes,9,opt,name=roundEventData,proto3,oneof"`
+}
+
+type Event_DeathEventData_ struct {
+	DeathEventData *Event_DeathEventData `protobuf:"bytes,10,opt,name=deathEventData,proto3,oneof"`
+}
+
+type Event_ResumeEventData_ struct {
+	ResumeEventData *Event_ResumeEventData `protobuf:"bytes,11,opt,name=resumeEventData,proto3,oneof"`
+}
+
 func (*Event_JoinEventData_) isEvent_Data() {}
 
 func (*Event_QuitEventData_) isEvent_Data() {}
@@ -236,17 +402,119 @@ func (*Event_SnapshotEventData_) isEvent_Data() {}
 
 func (*Event_DeltaEventData_) isEvent_Data() {}
 
+func (*Event_SpectateEventData_) isEvent_Data() {}
+
+func (*Event_RoundEventData_) isEvent_Data() {}
+
+func (*Event_DeathEventData_) isEvent_Data() {}
+
+func (*Event_ResumeEventData_) isEvent_Data() {}
+
+// The settings a recorded game was created with, so that it can be rebuilt.
+type MatchConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          GameMode               `protobuf:"varint,1,opt,name=mode,proto3,enum=dogfight.GameMode" json:"mode,omitempty"`
+	ScoreLimit    uint32                 `protobuf:"varint,2,opt,name=scoreLimit,proto3" json:"scoreLimit,omitempty"` // or 0 if unlimited
+	BotCount      uint32                 `protobuf:"varint,3,opt,name=botCount,proto3" json:"botCount,omitempty"`
+	RewindWindow  uint32                 `protobuf:"varint,4,opt,name=rewindWindow,proto3" json:"rewindWindow,omitempty"`   // ticks
+	RoundDuration uint32                 `protobuf:"varint,5,opt,name=roundDuration,proto3" json:"roundDuration,omitempty"` // ticks, or 0 if untimed
+	WorldSize     float64                `protobuf:"fixed64,6,opt,name=worldSize,proto3" json:"worldSize,omitempty"`
+	Boundary      Boundary               `protobuf:"varint,7,opt,name=boundary,proto3,enum=dogfight.Boundary" json:"boundary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchConfig) Reset() {
+	*x = MatchConfig{}
+	mi := &file_event_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchConfig) ProtoMessage() {}
+
+func (x *MatchConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchConfig.ProtoReflect.Descriptor instead.
+func (*MatchConfig) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MatchConfig) GetMode() GameMode {
+	if x != nil {
+		return x.Mode
+	}
+	return GameMode_GAME_MODE_FREE_FOR_ALL
+}
+
+func (x *MatchConfig) GetScoreLimit() uint32 {
+	if x != nil {
+		return x.ScoreLimit
+	}
+	return 0
+}
+
+func (x *MatchConfig) GetBotCount() uint32 {
+	if x != nil {
+		return x.BotCount
+	}
+	return 0
+}
+
+func (x *MatchConfig) GetRewindWindow() uint32 {
+	if x != nil {
+		return x.RewindWindow
+	}
+	return 0
+}
+
+func (x *MatchConfig) GetRoundDuration() uint32 {
+	if x != nil {
+		return x.RoundDuration
+	}
+	return 0
+}
+
+func (x *MatchConfig) GetWorldSize() float64 {
+	if x != nil {
+		return x.WorldSize
+	}
+	return 0
+}
+
+func (x *MatchConfig) GetBoundary() Boundary {
+	if x != nil {
+		return x.Boundary
+	}
+	return Boundary_BOUNDARY_WRAP
+}
+
 type Event_JoinEventData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	IsBot         bool                   `protobuf:"varint,3,opt,name=isBot,proto3" json:"isBot,omitempty"` // bots are added by the server, so rebuilds skip them
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Event_JoinEventData) Reset() {
 	*x = Event_JoinEventData{}
-	mi := &file_event_proto_msgTypes[1]
+	mi := &file_event_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -258,7 +526,7 @@ func (x *Event_JoinEventData) String() string {
 func (*Event_JoinEventData) ProtoMessage() {}
 
 func (x *Event_JoinEventData) ProtoReflect() protoreflect.Message {
-	mi := &file_event_proto_msgTypes[1]
+	mi := &file_event_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -288,16 +556,24 @@ func (x *Event_JoinEventData) GetUsername() string {
 	return ""
 }
 
+func (x *Event_JoinEventData) GetIsBot() bool {
+	if x != nil {
+		return x.IsBot
+	}
+	return false
+}
+
 type Event_QuitEventData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IsBot         bool                   `protobuf:"varint,2,opt,name=isBot,proto3" json:"isBot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Event_QuitEventData) Reset() {
 	*x = Event_QuitEventData{}
-	mi := &file_event_proto_msgTypes[2]
+	mi := &file_event_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -309,7 +585,7 @@ func (x *Event_QuitEventData) String() string {
 func (*Event_QuitEventData) ProtoMessage() {}
 
 func (x *Event_QuitEventData) ProtoReflect() protoreflect.Message {
-	mi := &file_event_proto_msgTypes[2]
+	mi := &file_event_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -332,16 +608,24 @@ func (x *Event_QuitEventData) GetId() string {
 	return ""
 }
 
+func (x *Event_QuitEventData) GetIsBot() bool {
+	if x != nil {
+		return x.IsBot
+	}
+	return false
+}
+
 type Event_RespawnEventData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IsBot         bool                   `protobuf:"varint,2,opt,name=isBot,proto3" json:"isBot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Event_RespawnEventData) Reset() {
 	*x = Event_RespawnEventData{}
-	mi := &file_event_proto_msgTypes[3]
+	mi := &file_event_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -353,7 +637,7 @@ func (x *Event_RespawnEventData) String() string {
 func (*Event_RespawnEventData) ProtoMessage() {}
 
 func (x *Event_RespawnEventData) ProtoReflect() protoreflect.Message {
-	mi := &file_event_proto_msgTypes[3]
+	mi := &file_event_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -376,19 +660,28 @@ func (x *Event_RespawnEventData) GetId() string {
 	return ""
 }
 
+func (x *Event_RespawnEventData) GetIsBot() bool {
+	if x != nil {
+		return x.IsBot
+	}
+	return false
+}
+
 type Event_InputEventData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	MouseX        float64                `protobuf:"fixed64,2,opt,name=mouseX,proto3" json:"mouseX,omitempty"`
 	MouseY        float64                `protobuf:"fixed64,3,opt,name=mouseY,proto3" json:"mouseY,omitempty"`
 	MousePressed  bool                   `protobuf:"varint,4,opt,name=mousePressed,proto3" json:"mousePressed,omitempty"`
+	Sequence      uint32                 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Tick          uint32                 `protobuf:"varint,6,opt,name=tick,proto3" json:"tick,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Event_InputEventData) Reset() {
 	*x = Event_InputEventData{}
-	mi := &file_event_proto_msgTypes[4]
+	mi := &file_event_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -400,7 +693,7 @@ func (x *Event_InputEventData) String() string {
 func (*Event_InputEventData) ProtoMessage() {}
 
 func (x *Event_InputEventData) ProtoReflect() protoreflect.Message {
-	mi := &file_event_proto_msgTypes[4]
+	mi := &file_event_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,17 +737,36 @@ func (x *Event_InputEventData) GetMousePressed() bool {
 	return false
 }
 
+func (x *Event_InputEventData) GetSequence() uint32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Event_InputEventData) GetTick() uint32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
 type Event_SnapshotEventData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Timestamp     float64                `protobuf:"fixed64,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Entities      []*EntityData          `protobuf:"bytes,2,rep,name=entities,proto3" json:"entities,omitempty"`
+	Seed          uint32                 `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"` // only set in recordings
+	WorldSize     float64                `protobuf:"fixed64,4,opt,name=worldSize,proto3" json:"worldSize,omitempty"`
+	Boundary      Boundary               `protobuf:"varint,5,opt,name=boundary,proto3,enum=dogfight.Boundary" json:"boundary,omitempty"`
+	ZoneSize      float64                `protobuf:"fixed64,6,opt,name=zoneSize,proto3" json:"zoneSize,omitempty"` // side of the safe zone, or 0 if there is no zone
+	Config        *MatchConfig           `protobuf:"bytes,7,opt,name=config,proto3" json:"config,omitempty"`       // only set in recordings
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Event_SnapshotEventData) Reset() {
 	*x = Event_SnapshotEventData{}
-	mi := &file_event_proto_msgTypes[5]
+	mi := &file_event_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -466,7 +778,7 @@ func (x *Event_SnapshotEventData) String() string {
 func (*Event_SnapshotEventData) ProtoMessage() {}
 
 func (x *Event_SnapshotEventData) ProtoReflect() protoreflect.Message {
-	mi := &file_event_proto_msgTypes[5]
+	mi := &file_event_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,18 +808,58 @@ func (x *Event_SnapshotEventData) GetEntities() []*EntityData {
 	return nil
 }
 
+func (x *Event_SnapshotEventData) GetSeed() uint32 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *Event_SnapshotEventData) GetWorldSize() float64 {
+	if x != nil {
+		return x.WorldSize
+	}
+	return 0
+}
+
+func (x *Event_SnapshotEventData) GetBoundary() Boundary {
+	if x != nil {
+		return x.Boundary
+	}
+	return Boundary_BOUNDARY_WRAP
+}
+
+func (x *Event_SnapshotEventData) GetZoneSize() float64 {
+	if x != nil {
+		return x.ZoneSize
+	}
+	return 0
+}
+
+func (x *Event_SnapshotEventData) GetConfig() *MatchConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
 type Event_DeltaEventData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Timestamp     float64                `protobuf:"fixed64,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Updated       []*EntityData          `protobuf:"bytes,2,rep,name=updated,proto3" json:"updated,omitempty"`
 	Removed       []string               `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"`
+	Changed       []*EntityDelta         `protobuf:"bytes,4,rep,name=changed,proto3" json:"changed,omitempty"`
+	Hidden        []string               `protobuf:"bytes,5,rep,name=hidden,proto3" json:"hidden,omitempty"`
+	Tick          uint32                 `protobuf:"varint,6,opt,name=tick,proto3" json:"tick,omitempty"`
+	InputSequence uint32                 `protobuf:"varint,7,opt,name=inputSequence,proto3" json:"inputSequence,omitempty"`
+	ZoneSize      float64                `protobuf:"fixed64,8,opt,name=zoneSize,proto3" json:"zoneSize,omitempty"` // side of the safe zone, or 0 if there is no zone
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Event_DeltaEventData) Reset() {
 	*x = Event_DeltaEventData{}
-	mi := &file_event_proto_msgTypes[6]
+	mi := &file_event_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -519,7 +871,7 @@ func (x *Event_DeltaEventData) String() string {
 func (*Event_DeltaEventData) ProtoMessage() {}
 
 func (x *Event_DeltaEventData) ProtoReflect() protoreflect.Message {
-	mi := &file_event_proto_msgTypes[6]
+	mi := &file_event_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,11 +908,363 @@ func (x *Event_DeltaEventData) GetRemoved() []string {
 	return nil
 }
 
+func (x *Event_DeltaEventData) GetChanged() []*EntityDelta {
+	if x != nil {
+		return x.Changed
+	}
+	return nil
+}
+
+func (x *Event_DeltaEventData) GetHidden() []string {
+	if x != nil {
+		return x.Hidden
+	}
+	return nil
+}
+
+func (x *Event_DeltaEventData) GetTick() uint32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *Event_DeltaEventData) GetInputSequence() uint32 {
+	if x != nil {
+		return x.InputSequence
+	}
+	return 0
+}
+
+func (x *Event_DeltaEventData) GetZoneSize() float64 {
+	if x != nil {
+		return x.ZoneSize
+	}
+	return 0
+}
+
+type Event_SpectateEventData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetId      string                 `protobuf:"bytes,1,opt,name=targetId,proto3" json:"targetId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event_SpectateEventData) Reset() {
+	*x = Event_SpectateEventData{}
+	mi := &file_event_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event_SpectateEventData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_SpectateEventData) ProtoMessage() {}
+
+func (x *Event_SpectateEventData) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_SpectateEventData.ProtoReflect.Descriptor instead.
+func (*Event_SpectateEventData) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{0, 6}
+}
+
+func (x *Event_SpectateEventData) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+type Event_RoundEventData struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	Mode          GameMode                      `protobuf:"varint,1,opt,name=mode,proto3,enum=dogfight.GameMode" json:"mode,omitempty"`
+	Round         uint32                        `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	IsOver        bool                          `protobuf:"varint,3,opt,name=isOver,proto3" json:"isOver,omitempty"`     // whether the round ended, or just started
+	Duration      uint32                        `protobuf:"varint,4,opt,name=duration,proto3" json:"duration,omitempty"` // length of the round in ticks, or 0 if untimed
+	Scores        []*Event_RoundEventData_Score `protobuf:"bytes,5,rep,name=scores,proto3" json:"scores,omitempty"`      // sorted from highest to lowest
+	WinnerId      string                        `protobuf:"bytes,6,opt,name=winnerId,proto3" json:"winnerId,omitempty"`
+	WinnerTeam    uint32                        `protobuf:"varint,7,opt,name=winnerTeam,proto3" json:"winnerTeam,omitempty"`
+	StartTick     uint32                        `protobuf:"varint,8,opt,name=startTick,proto3" json:"startTick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event_RoundEventData) Reset() {
+	*x = Event_RoundEventData{}
+	mi := &file_event_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event_RoundEventData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_RoundEventData) ProtoMessage() {}
+
+func (x *Event_RoundEventData) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_RoundEventData.ProtoReflect.Descriptor instead.
+func (*Event_RoundEventData) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{0, 7}
+}
+
+func (x *Event_RoundEventData) GetMode() GameMode {
+	if x != nil {
+		return x.Mode
+	}
+	return GameMode_GAME_MODE_FREE_FOR_ALL
+}
+
+func (x *Event_RoundEventData) GetRound() uint32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *Event_RoundEventData) GetIsOver() bool {
+	if x != nil {
+		return x.IsOver
+	}
+	return false
+}
+
+func (x *Event_RoundEventData) GetDuration() uint32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Event_RoundEventData) GetScores() []*Event_RoundEventData_Score {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *Event_RoundEventData) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *Event_RoundEventData) GetWinnerTeam() uint32 {
+	if x != nil {
+		return x.WinnerTeam
+	}
+	return 0
+}
+
+func (x *Event_RoundEventData) GetStartTick() uint32 {
+	if x != nil {
+		return x.StartTick
+	}
+	return 0
+}
+
+type Event_DeathEventData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VictimId      string                 `protobuf:"bytes,1,opt,name=victimId,proto3" json:"victimId,omitempty"`
+	KillerId      string                 `protobuf:"bytes,2,opt,name=killerId,proto3" json:"killerId,omitempty"` // or empty if no player caused the death
+	Cause         DamageCause            `protobuf:"varint,3,opt,name=cause,proto3,enum=dogfight.DamageCause" json:"cause,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event_DeathEventData) Reset() {
+	*x = Event_DeathEventData{}
+	mi := &file_event_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event_DeathEventData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_DeathEventData) ProtoMessage() {}
+
+func (x *Event_DeathEventData) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_DeathEventData.ProtoReflect.Descriptor instead.
+func (*Event_DeathEventData) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{0, 8}
+}
+
+func (x *Event_DeathEventData) GetVictimId() string {
+	if x != nil {
+		return x.VictimId
+	}
+	return ""
+}
+
+func (x *Event_DeathEventData) GetKillerId() string {
+	if x != nil {
+		return x.KillerId
+	}
+	return ""
+}
+
+func (x *Event_DeathEventData) GetCause() DamageCause {
+	if x != nil {
+		return x.Cause
+	}
+	return DamageCause_DAMAGE_CAUSE_UNKNOWN
+}
+
+// Only used in recordings, when a client reconnects to its session.
+type Event_ResumeEventData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event_ResumeEventData) Reset() {
+	*x = Event_ResumeEventData{}
+	mi := &file_event_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event_ResumeEventData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_ResumeEventData) ProtoMessage() {}
+
+func (x *Event_ResumeEventData) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_ResumeEventData.ProtoReflect.Descriptor instead.
+func (*Event_ResumeEventData) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{0, 9}
+}
+
+func (x *Event_ResumeEventData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Event_RoundEventData_Score struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Team          uint32                 `protobuf:"varint,3,opt,name=team,proto3" json:"team,omitempty"`
+	Kills         uint32                 `protobuf:"varint,4,opt,name=kills,proto3" json:"kills,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event_RoundEventData_Score) Reset() {
+	*x = Event_RoundEventData_Score{}
+	mi := &file_event_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event_RoundEventData_Score) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_RoundEventData_Score) ProtoMessage() {}
+
+func (x *Event_RoundEventData_Score) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_RoundEventData_Score.ProtoReflect.Descriptor instead.
+func (*Event_RoundEventData_Score) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{0, 7, 0}
+}
+
+func (x *Event_RoundEventData_Score) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Event_RoundEventData_Score) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Event_RoundEventData_Score) GetTeam() uint32 {
+	if x != nil {
+		return x.Team
+	}
+	return 0
+}
+
+func (x *Event_RoundEventData_Score) GetKills() uint32 {
+	if x != nil {
+		return x.Kills
+	}
+	return 0
+}
+
 var File_event_proto protoreflect.FileDescriptor
 
 const file_event_proto_rawDesc = "" +
 	"\n" +
-	"\vevent.proto\x12\bdogfight\x1a\x0eentities.proto\"\xd4\a\n" +
+	"\vevent.proto\x12\bdogfight\x1a\x0eentities.proto\"\x90\x12\n" +
 	"\x05Event\x12'\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x13.dogfight.EventTypeR\x04type\x12E\n" +
 	"\rjoinEventData\x18\x02 \x01(\v2\x1d.dogfight.Event.JoinEventDataH\x00R\rjoinEventData\x12E\n" +
@@ -568,27 +1272,89 @@ const file_event_proto_rawDesc = "" +
 	"\x10respawnEventData\x18\x04 \x01(\v2 .dogfight.Event.RespawnEventDataH\x00R\x10respawnEventData\x12H\n" +
 	"\x0einputEventData\x18\x05 \x01(\v2\x1e.dogfight.Event.InputEventDataH\x00R\x0einputEventData\x12Q\n" +
 	"\x11snapshotEventData\x18\x06 \x01(\v2!.dogfight.Event.SnapshotEventDataH\x00R\x11snapshotEventData\x12H\n" +
-	"\x0edeltaEventData\x18\a \x01(\v2\x1e.dogfight.Event.DeltaEventDataH\x00R\x0edeltaEventData\x1a;\n" +
+	"\x0edeltaEventData\x18\a \x01(\v2\x1e.dogfight.Event.DeltaEventDataH\x00R\x0edeltaEventData\x12Q\n" +
+	"\x11spectateEventData\x18\b \x01(\v2!.dogfight.Event.SpectateEventDataH\x00R\x11spectateEventData\x12H\n" +
+	"\x0eroundEventData\x18\t \x01(\v2\x1e.dogfight.Event.RoundEventDataH\x00R\x0eroundEventData\x12H\n" +
+	"\x0edeathEventData\x18\n" +
+	" \x01(\v2\x1e.dogfight.Event.DeathEventDataH\x00R\x0edeathEventData\x12K\n" +
+	"\x0fresumeEventData\x18\v \x01(\v2\x1f.dogfight.Event.ResumeEventDataH\x00R\x0fresumeEventData\x1aQ\n" +
 	"\rJoinEventData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
-	"\busername\x18\x02 \x01(\tR\busername\x1a\x1f\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05isBot\x18\x03 \x01(\bR\x05isBot\x1a5\n" +
 	"\rQuitEventData\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x1a\"\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05isBot\x18\x02 \x01(\bR\x05isBot\x1a8\n" +
 	"\x10RespawnEventData\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x1at\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05isBot\x18\x02 \x01(\bR\x05isBot\x1a\xa4\x01\n" +
 	"\x0eInputEventData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
 	"\x06mouseX\x18\x02 \x01(\x01R\x06mouseX\x12\x16\n" +
 	"\x06mouseY\x18\x03 \x01(\x01R\x06mouseY\x12\"\n" +
-	"\fmousePressed\x18\x04 \x01(\bR\fmousePressed\x1ac\n" +
+	"\fmousePressed\x18\x04 \x01(\bR\fmousePressed\x12\x1a\n" +
+	"\bsequence\x18\x05 \x01(\rR\bsequence\x12\x12\n" +
+	"\x04tick\x18\x06 \x01(\rR\x04tick\x1a\x90\x02\n" +
 	"\x11SnapshotEventData\x12\x1c\n" +
 	"\ttimestamp\x18\x01 \x01(\x01R\ttimestamp\x120\n" +
-	"\bentities\x18\x02 \x03(\v2\x14.dogfight.EntityDataR\bentities\x1ax\n" +
+	"\bentities\x18\x02 \x03(\v2\x14.dogfight.EntityDataR\bentities\x12\x12\n" +
+	"\x04seed\x18\x03 \x01(\rR\x04seed\x12\x1c\n" +
+	"\tworldSize\x18\x04 \x01(\x01R\tworldSize\x12.\n" +
+	"\bboundary\x18\x05 \x01(\x0e2\x12.dogfight.BoundaryR\bboundary\x12\x1a\n" +
+	"\bzoneSize\x18\x06 \x01(\x01R\bzoneSize\x12-\n" +
+	"\x06config\x18\a \x01(\v2\x15.dogfight.MatchConfigR\x06config\x1a\x97\x02\n" +
 	"\x0eDeltaEventData\x12\x1c\n" +
 	"\ttimestamp\x18\x01 \x01(\x01R\ttimestamp\x12.\n" +
 	"\aupdated\x18\x02 \x03(\v2\x14.dogfight.EntityDataR\aupdated\x12\x18\n" +
-	"\aremoved\x18\x03 \x03(\tR\aremovedB\x06\n" +
-	"\x04data*\xaa\x01\n" +
+	"\aremoved\x18\x03 \x03(\tR\aremoved\x12/\n" +
+	"\achanged\x18\x04 \x03(\v2\x15.dogfight.EntityDeltaR\achanged\x12\x16\n" +
+	"\x06hidden\x18\x05 \x03(\tR\x06hidden\x12\x12\n" +
+	"\x04tick\x18\x06 \x01(\rR\x04tick\x12$\n" +
+	"\rinputSequence\x18\a \x01(\rR\rinputSequence\x12\x1a\n" +
+	"\bzoneSize\x18\b \x01(\x01R\bzoneSize\x1a/\n" +
+	"\x11SpectateEventData\x12\x1a\n" +
+	"\btargetId\x18\x01 \x01(\tR\btargetId\x1a\xf9\x02\n" +
+	"\x0eRoundEventData\x12&\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x12.dogfight.GameModeR\x04mode\x12\x14\n" +
+	"\x05round\x18\x02 \x01(\rR\x05round\x12\x16\n" +
+	"\x06isOver\x18\x03 \x01(\bR\x06isOver\x12\x1a\n" +
+	"\bduration\x18\x04 \x01(\rR\bduration\x12<\n" +
+	"\x06scores\x18\x05 \x03(\v2$.dogfight.Event.RoundEventData.ScoreR\x06scores\x12\x1a\n" +
+	"\bwinnerId\x18\x06 \x01(\tR\bwinnerId\x12\x1e\n" +
+	"\n" +
+	"winnerTeam\x18\a \x01(\rR\n" +
+	"winnerTeam\x12\x1c\n" +
+	"\tstartTick\x18\b \x01(\rR\tstartTick\x1a]\n" +
+	"\x05Score\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x12\n" +
+	"\x04team\x18\x03 \x01(\rR\x04team\x12\x14\n" +
+	"\x05kills\x18\x04 \x01(\rR\x05kills\x1au\n" +
+	"\x0eDeathEventData\x12\x1a\n" +
+	"\bvictimId\x18\x01 \x01(\tR\bvictimId\x12\x1a\n" +
+	"\bkillerId\x18\x02 \x01(\tR\bkillerId\x12+\n" +
+	"\x05cause\x18\x03 \x01(\x0e2\x15.dogfight.DamageCauseR\x05cause\x1a!\n" +
+	"\x0fResumeEventData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02idB\x06\n" +
+	"\x04data\"\x89\x02\n" +
+	"\vMatchConfig\x12&\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\x12.dogfight.GameModeR\x04mode\x12\x1e\n" +
+	"\n" +
+	"scoreLimit\x18\x02 \x01(\rR\n" +
+	"scoreLimit\x12\x1a\n" +
+	"\bbotCount\x18\x03 \x01(\rR\bbotCount\x12\"\n" +
+	"\frewindWindow\x18\x04 \x01(\rR\frewindWindow\x12$\n" +
+	"\rroundDuration\x18\x05 \x01(\rR\rroundDuration\x12\x1c\n" +
+	"\tworldSize\x18\x06 \x01(\x01R\tworldSize\x12.\n" +
+	"\bboundary\x18\a \x01(\x0e2\x12.dogfight.BoundaryR\bboundary*_\n" +
+	"\bGameMode\x12\x1a\n" +
+	"\x16GAME_MODE_FREE_FOR_ALL\x10\x00\x12\x1d\n" +
+	"\x19GAME_MODE_TEAM_DEATHMATCH\x10\x01\x12\x18\n" +
+	"\x14GAME_MODE_FIRST_TO_N\x10\x02*O\n" +
+	"\bBoundary\x12\x11\n" +
+	"\rBOUNDARY_WRAP\x10\x00\x12\x13\n" +
+	"\x0fBOUNDARY_BOUNCE\x10\x01\x12\x1b\n" +
+	"\x17BOUNDARY_SHRINKING_ZONE\x10\x02*\x86\x02\n" +
 	"\tEventType\x12\x16\n" +
 	"\x12EVENT_TYPE_UNKNOWN\x10\x00\x12\x13\n" +
 	"\x0fEVENT_TYPE_JOIN\x10\x01\x12\x13\n" +
@@ -596,7 +1362,12 @@ const file_event_proto_rawDesc = "" +
 	"\x12EVENT_TYPE_RESPAWN\x10\x03\x12\x14\n" +
 	"\x10EVENT_TYPE_INPUT\x10\x04\x12\x17\n" +
 	"\x13EVENT_TYPE_SNAPSHOT\x10\x05\x12\x14\n" +
-	"\x10EVENT_TYPE_DELTA\x10\x06B\x05Z\x03/pbb\x06proto3"
+	"\x10EVENT_TYPE_DELTA\x10\x06\x12\x17\n" +
+	"\x13EVENT_TYPE_SPECTATE\x10\a\x12\x14\n" +
+	"\x10EVENT_TYPE_ROUND\x10\b\x12\x14\n" +
+	"\x10EVENT_TYPE_DEATH\x10\t\x12\x15\n" +
+	"\x11EVENT_TYPE_RESUME\x10\n" +
+	"B\x05Z\x03/pbb\x06proto3"
 
 var (
 	file_event_proto_rawDescOnce sync.Once
@@ -610,34 +1381,56 @@ func file_event_proto_rawDescGZIP() []byte {
 	return file_event_proto_rawDescData
 }
 
-var file_event_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_event_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_event_proto_goTypes = []any{
-	(EventType)(0),                  // 0: dogfight.EventType
-	(*Event)(nil),                   // 1: dogfight.Event
-	(*Event_JoinEventData)(nil),     // 2: dogfight.Event.JoinEventData
-	(*Event_QuitEventData)(nil),     // 3: dogfight.Event.QuitEventData
-	(*Event_RespawnEventData)(nil),  // 4: dogfight.Event.RespawnEventData
-	(*Event_InputEventData)(nil),    // 5: dogfight.Event.InputEventData
-	(*Event_SnapshotEventData)(nil), // 6: dogfight.Event.SnapshotEventData
-	(*Event_DeltaEventData)(nil),    // 7: dogfight.Event.DeltaEventData
-	(*EntityData)(nil),              // 8: dogfight.EntityData
+	(GameMode)(0),                      // 0: dogfight.GameMode
+	(Boundary)(0),                      // 1: dogfight.Boundary
+	(EventType)(0),                     // 2: dogfight.EventType
+	(*Event)(nil),                      // 3: dogfight.Event
+	(*MatchConfig)(nil),                // 4: dogfight.MatchConfig
+	(*Event_JoinEventData)(nil),        // 5: dogfight.Event.JoinEventData
+	(*Event_QuitEventData)(nil),        // 6: dogfight.Event.QuitEventData
+	(*Event_RespawnEventData)(nil),     // 7: dogfight.Event.RespawnEventData
+	(*Event_InputEventData)(nil),       // 8: dogfight.Event.InputEventData
+	(*Event_SnapshotEventData)(nil),    // 9: dogfight.Event.SnapshotEventData
+	(*Event_DeltaEventData)(nil),       // 10: dogfight.Event.DeltaEventData
+	(*Event_SpectateEventData)(nil),    // 11: dogfight.Event.SpectateEventData
+	(*Event_RoundEventData)(nil),       // 12: dogfight.Event.RoundEventData
+	(*Event_DeathEventData)(nil),       // 13: dogfight.Event.DeathEventData
+	(*Event_ResumeEventData)(nil),      // 14: dogfight.Event.ResumeEventData
+	(*Event_RoundEventData_Score)(nil), // 15: dogfight.Event.RoundEventData.Score
+	(*EntityData)(nil),                 // 16: dogfight.EntityData
+	(*EntityDelta)(nil),                // 17: dogfight.EntityDelta
+	(DamageCause)(0),                   // 18: dogfight.DamageCause
 }
 var file_event_proto_depIdxs = []int32{
-	0, // 0: dogfight.Event.type:type_name -> dogfight.EventType
-	2, // 1: dogfight.Event.joinEventData:type_name -> dogfight.Event.JoinEventData
-	3, // 2: dogfight.Event.quitEventData:type_name -> dogfight.Event.QuitEventData
-	4, // 3: dogfight.Event.respawnEventData:type_name -> dogfight.Event.RespawnEventData
-	5, // 4: dogfight.Event.inputEventData:type_name -> dogfight.Event.InputEventData
-	6, // 5: dogfight.Event.snapshotEventData:type_name -> dogfight.Event.SnapshotEventData
-	7, // 6: dogfight.Event.deltaEventData:type_name -> dogfight.Event.DeltaEventData
-	8, // 7: dogfight.Event.SnapshotEventData.entities:type_name -> dogfight.EntityData
-	8, // 8: dogfight.Event.DeltaEventData.updated:type_name -> dogfight.EntityData
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	2,  // 0: dogfight.Event.type:type_name -> dogfight.EventType
+	5,  // 1: dogfight.Event.joinEventData:type_name -> dogfight.Event.JoinEventData
+	6,  // 2: dogfight.Event.quitEventData:type_name -> dogfight.Event.QuitEventData
+	7,  // 3: dogfight.Event.respawnEventData:type_name -> dogfight.Event.RespawnEventData
+	8,  // 4: dogfight.Event.inputEventData:type_name -> dogfight.Event.InputEventData
+	9,  // 5: dogfight.Event.snapshotEventData:type_name -> dogfight.Event.SnapshotEventData
+	10, // 6: dogfight.Event.deltaEventData:type_name -> dogfight.Event.DeltaEventData
+	11, // 7: dogfight.Event.spectateEventData:type_name -> dogfight.Event.SpectateEventData
+	12, // 8: dogfight.Event.roundEventData:type_name -> dogfight.Event.RoundEventData
+	13, // 9: dogfight.Event.deathEventData:type_name -> dogfight.Event.DeathEventData
+	14, // 10: dogfight.Event.resumeEventData:type_name -> dogfight.Event.ResumeEventData
+	0,  // 11: dogfight.MatchConfig.mode:type_name -> dogfight.GameMode
+	1,  // 12: dogfight.MatchConfig.boundary:type_name -> dogfight.Boundary
+	16, // 13: dogfight.Event.SnapshotEventData.entities:type_name -> dogfight.EntityData
+	1,  // 14: dogfight.Event.SnapshotEventData.boundary:type_name -> dogfight.Boundary
+	4,  // 15: dogfight.Event.SnapshotEventData.config:type_name -> dogfight.MatchConfig
+	16, // 16: dogfight.Event.DeltaEventData.updated:type_name -> dogfight.EntityData
+	17, // 17: dogfight.Event.DeltaEventData.changed:type_name -> dogfight.EntityDelta
+	0,  // 18: dogfight.Event.RoundEventData.mode:type_name -> dogfight.GameMode
+	15, // 19: dogfight.Event.RoundEventData.scores:type_name -> dogfight.Event.RoundEventData.Score
+	18, // 20: dogfight.Event.DeathEventData.cause:type_name -> dogfight.DamageCause
+	21, // [21:21] is the sub-list for method output_type
+	21, // [21:21] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_event_proto_init() }
@@ -653,14 +1446,18 @@ func file_event_proto_init() {
 		(*Event_InputEventData_)(nil),
 		(*Event_SnapshotEventData_)(nil),
 		(*Event_DeltaEventData_)(nil),
+		(*Event_SpectateEventData_)(nil),
+		(*Event_RoundEventData_)(nil),
+		(*Event_DeathEventData_)(nil),
+		(*Event_ResumeEventData_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      3,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
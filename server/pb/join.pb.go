@@ -25,6 +25,8 @@ type JoinRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 	RoomId        *string                `protobuf:"bytes,2,opt,name=roomId,proto3,oneof" json:"roomId,omitempty"`
+	Spectate      bool                   `protobuf:"varint,3,opt,name=spectate,proto3" json:"spectate,omitempty"`
+	Password      *string                `protobuf:"bytes,4,opt,name=password,proto3,oneof" json:"password,omitempty"` // only needed for private rooms
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -73,11 +75,26 @@ func (x *JoinRequest) GetRoomId() string {
 	return ""
 }
 
+func (x *JoinRequest) GetSpectate() bool {
+	if x != nil {
+		return x.Spectate
+	}
+	return false
+}
+
+func (x *JoinRequest) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
 type JoinResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ClientId      string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
 	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
 	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	Spectator     bool                   `protobuf:"varint,4,opt,name=spectator,proto3" json:"spectator,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -133,20 +150,228 @@ func (x *JoinResponse) GetToken() string {
 	return ""
 }
 
+func (x *JoinResponse) GetSpectator() bool {
+	if x != nil {
+		return x.Spectator
+	}
+	return false
+}
+
+type ResumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeRequest) Reset() {
+	*x = ResumeRequest{}
+	mi := &file_join_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeRequest) ProtoMessage() {}
+
+func (x *ResumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_join_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeRequest.ProtoReflect.Descriptor instead.
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
+	return file_join_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResumeRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type CreateRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Password      *string                `protobuf:"bytes,1,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	MaxPlayers    uint32                 `protobuf:"varint,2,opt,name=maxPlayers,proto3" json:"maxPlayers,omitempty"` // or 0 to use the room capacity
+	Mode          GameMode               `protobuf:"varint,3,opt,name=mode,proto3,enum=dogfight.GameMode" json:"mode,omitempty"`
+	ScoreLimit    uint32                 `protobuf:"varint,4,opt,name=scoreLimit,proto3" json:"scoreLimit,omitempty"`       // kills to win a round, or 0 for the mode's default
+	RoundDuration uint32                 `protobuf:"varint,5,opt,name=roundDuration,proto3" json:"roundDuration,omitempty"` // length of a round in seconds, or 0 if untimed
+	WorldSize     uint32                 `protobuf:"varint,6,opt,name=worldSize,proto3" json:"worldSize,omitempty"`         // width and height of the world, or 0 for the default
+	Boundary      Boundary               `protobuf:"varint,7,opt,name=boundary,proto3,enum=dogfight.Boundary" json:"boundary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomRequest) Reset() {
+	*x = CreateRoomRequest{}
+	mi := &file_join_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomRequest) ProtoMessage() {}
+
+func (x *CreateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_join_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_join_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateRoomRequest) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *CreateRoomRequest) GetMaxPlayers() uint32 {
+	if x != nil {
+		return x.MaxPlayers
+	}
+	return 0
+}
+
+func (x *CreateRoomRequest) GetMode() GameMode {
+	if x != nil {
+		return x.Mode
+	}
+	return GameMode_GAME_MODE_FREE_FOR_ALL
+}
+
+func (x *CreateRoomRequest) GetScoreLimit() uint32 {
+	if x != nil {
+		return x.ScoreLimit
+	}
+	return 0
+}
+
+func (x *CreateRoomRequest) GetRoundDuration() uint32 {
+	if x != nil {
+		return x.RoundDuration
+	}
+	return 0
+}
+
+func (x *CreateRoomRequest) GetWorldSize() uint32 {
+	if x != nil {
+		return x.WorldSize
+	}
+	return 0
+}
+
+func (x *CreateRoomRequest) GetBoundary() Boundary {
+	if x != nil {
+		return x.Boundary
+	}
+	return Boundary_BOUNDARY_WRAP
+}
+
+type CreateRoomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomResponse) Reset() {
+	*x = CreateRoomResponse{}
+	mi := &file_join_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomResponse) ProtoMessage() {}
+
+func (x *CreateRoomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_join_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomResponse.ProtoReflect.Descriptor instead.
+func (*CreateRoomResponse) Descriptor() ([]byte, []int) {
+	return file_join_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateRoomResponse) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
 var File_join_proto protoreflect.FileDescriptor
 
 const file_join_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"join.proto\x12\bdogfight\"Q\n" +
+	"join.proto\x12\bdogfight\x1a\vevent.proto\"\x9b\x01\n" +
 	"\vJoinRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1b\n" +
-	"\x06roomId\x18\x02 \x01(\tH\x00R\x06roomId\x88\x01\x01B\t\n" +
-	"\a_roomId\"T\n" +
+	"\x06roomId\x18\x02 \x01(\tH\x00R\x06roomId\x88\x01\x01\x12\x1a\n" +
+	"\bspectate\x18\x03 \x01(\bR\bspectate\x12\x1f\n" +
+	"\bpassword\x18\x04 \x01(\tH\x01R\bpassword\x88\x01\x01B\t\n" +
+	"\a_roomIdB\v\n" +
+	"\t_password\"r\n" +
 	"\fJoinResponse\x12\x1a\n" +
 	"\bclientId\x18\x01 \x01(\tR\bclientId\x12\x12\n" +
 	"\x04host\x18\x02 \x01(\tR\x04host\x12\x14\n" +
-	"\x05token\x18\x03 \x01(\tR\x05tokenB\x05Z\x03/pbb\x06proto3"
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1c\n" +
+	"\tspectator\x18\x04 \x01(\bR\tspectator\"%\n" +
+	"\rResumeRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x9d\x02\n" +
+	"\x11CreateRoomRequest\x12\x1f\n" +
+	"\bpassword\x18\x01 \x01(\tH\x00R\bpassword\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"maxPlayers\x18\x02 \x01(\rR\n" +
+	"maxPlayers\x12&\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x12.dogfight.GameModeR\x04mode\x12\x1e\n" +
+	"\n" +
+	"scoreLimit\x18\x04 \x01(\rR\n" +
+	"scoreLimit\x12$\n" +
+	"\rroundDuration\x18\x05 \x01(\rR\rroundDuration\x12\x1c\n" +
+	"\tworldSize\x18\x06 \x01(\rR\tworldSize\x12.\n" +
+	"\bboundary\x18\a \x01(\x0e2\x12.dogfight.BoundaryR\bboundaryB\v\n" +
+	"\t_password\",\n" +
+	"\x12CreateRoomResponse\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\tR\x06roomIdB\x05Z\x03/pbb\x06proto3"
 
 var (
 	file_join_proto_rawDescOnce sync.Once
@@ -160,17 +385,24 @@ func file_join_proto_rawDescGZIP() []byte {
 	return file_join_proto_rawDescData
 }
 
-var file_join_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_join_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_join_proto_goTypes = []any{
-	(*JoinRequest)(nil),  // 0: dogfight.JoinRequest
-	(*JoinResponse)(nil), // 1: dogfight.JoinResponse
+	(*JoinRequest)(nil),        // 0: dogfight.JoinRequest
+	(*JoinResponse)(nil),       // 1: dogfight.JoinResponse
+	(*ResumeRequest)(nil),      // 2: dogfight.ResumeRequest
+	(*CreateRoomRequest)(nil),  // 3: dogfight.CreateRoomRequest
+	(*CreateRoomResponse)(nil), // 4: dogfight.CreateRoomResponse
+	(GameMode)(0),              // 5: dogfight.GameMode
+	(Boundary)(0),              // 6: dogfight.Boundary
 }
 var file_join_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	5, // 0: dogfight.CreateRoomRequest.mode:type_name -> dogfight.GameMode
+	6, // 1: dogfight.CreateRoomRequest.boundary:type_name -> dogfight.Boundary
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_join_proto_init() }
@@ -178,14 +410,16 @@ func file_join_proto_init() {
 	if File_join_proto != nil {
 		return
 	}
+	file_event_proto_init()
 	file_join_proto_msgTypes[0].OneofWrappers = []any{}
+	file_join_proto_msgTypes[3].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_join_proto_rawDesc), len(file_join_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},